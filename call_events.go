@@ -0,0 +1,76 @@
+package twilio
+
+import (
+	"context"
+	"net/url"
+)
+
+// A CallRequestEventService lets you retrieve the HTTP requests Twilio made to
+// your application while handling a Call, and your application's responses.
+//
+// For the Voice Insights events for a Call, see CallEventsService.
+type CallRequestEventService struct {
+	client *Client
+}
+
+func callRequestEventPathPart(callSid string) string {
+	return callsPathPart + "/" + callSid + "/Events"
+}
+
+type CallRequestEvent struct {
+	Request  CallEventRequest  `json:"request"`
+	Response CallEventResponse `json:"response"`
+}
+
+type CallEventRequest struct {
+	Method     string            `json:"method"`
+	URL        string            `json:"url"`
+	Parameters map[string]string `json:"parameters"`
+}
+
+type CallEventResponse struct {
+	ContentType  string     `json:"content_type"`
+	DateCreated  TwilioTime `json:"date_created"`
+	ResponseBody string     `json:"response_body"`
+	ResponseCode int        `json:"response_code"`
+	// RequestDuration is the number of milliseconds your application took to
+	// respond.
+	RequestDuration int `json:"request_duration"`
+}
+
+type CallRequestEventPage struct {
+	Page
+	Events []*CallRequestEvent `json:"events"`
+}
+
+// GetPage returns a single Page of request events for the Call, filtered by
+// data. To retrieve multiple pages, use GetPageIterator.
+func (c *CallRequestEventService) GetPage(ctx context.Context, callSid string, data url.Values) (*CallRequestEventPage, error) {
+	return c.GetPageIterator(callSid, data).Next(ctx)
+}
+
+type CallRequestEventPageIterator struct {
+	p *PageIterator
+}
+
+// GetPageIterator returns a CallRequestEventPageIterator with the given page
+// filters. Call iterator.Next() to get the first page of resources (and again
+// to retrieve subsequent pages).
+func (c *CallRequestEventService) GetPageIterator(callSid string, data url.Values) *CallRequestEventPageIterator {
+	iter := NewPageIterator(c.client, data, callRequestEventPathPart(callSid))
+	return &CallRequestEventPageIterator{
+		p: iter,
+	}
+}
+
+// Next returns the next page of resources. If there are no more resources,
+// NoMoreResults is returned.
+func (c *CallRequestEventPageIterator) Next(ctx context.Context) (*CallRequestEventPage, error) {
+	ep := new(CallRequestEventPage)
+	err := c.p.Next(ctx, ep)
+	if err != nil {
+		return nil, err
+	}
+	c.p.SetNextPageURI(ep.NextPageURI)
+	return ep, nil
+}
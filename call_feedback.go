@@ -0,0 +1,62 @@
+package twilio
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// A CallFeedbackService lets you submit and retrieve quality feedback for a
+// Call.
+type CallFeedbackService struct {
+	client *Client
+}
+
+// A FeedbackIssue describes a problem with the quality of a Call.
+type FeedbackIssue string
+
+const (
+	FeedbackIssueAudioLatency      FeedbackIssue = "audio-latency"
+	FeedbackIssueDigitsNotCaptured FeedbackIssue = "digits-not-captured"
+	FeedbackIssueDroppedCall       FeedbackIssue = "dropped-call"
+	FeedbackIssueImperfectAudio    FeedbackIssue = "imperfect-audio"
+	FeedbackIssueIncorrectCallerID FeedbackIssue = "incorrect-caller-id"
+	FeedbackIssueOneWayAudio       FeedbackIssue = "one-way-audio"
+	FeedbackIssuePostDialDelay     FeedbackIssue = "post-dial-delay"
+	FeedbackIssueUnsolicitedCall   FeedbackIssue = "unsolicited-call"
+)
+
+type CallFeedback struct {
+	Sid         string          `json:"sid"`
+	AccountSid  string          `json:"account_sid"`
+	DateCreated TwilioTime      `json:"date_created"`
+	DateUpdated TwilioTime      `json:"date_updated"`
+	Issues      []FeedbackIssue `json:"issues"`
+	// QualityScore is a number from 1 (worst) to 5 (best).
+	QualityScore int `json:"quality_score"`
+}
+
+func callFeedbackPathPart(callSid string) string {
+	return callsPathPart + "/" + callSid + "/Feedback"
+}
+
+// Get returns the feedback for the Call with the given sid.
+func (c *CallFeedbackService) Get(ctx context.Context, callSid string) (*CallFeedback, error) {
+	feedback := new(CallFeedback)
+	err := c.client.MakeRequest(ctx, "GET", callFeedbackPathPart(callSid), nil, feedback)
+	return feedback, err
+}
+
+// Create submits feedback for the Call with the given sid. qualityScore must
+// be between 1 and 5. If feedback was already submitted for the Call, it is
+// replaced.
+func (c *CallFeedbackService) Create(ctx context.Context, callSid string, qualityScore int, issues ...FeedbackIssue) (*CallFeedback, error) {
+	data := url.Values{}
+	data.Set("QualityScore", strconv.Itoa(qualityScore))
+	for _, issue := range issues {
+		data.Add("Issue", string(issue))
+	}
+	feedback := new(CallFeedback)
+	err := c.client.CreateResource(ctx, callFeedbackPathPart(callSid), data, feedback)
+	return feedback, err
+}
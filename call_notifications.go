@@ -0,0 +1,82 @@
+package twilio
+
+import (
+	"context"
+	"net/url"
+)
+
+// A CallNotificationService lets you retrieve the errors and warnings that
+// Twilio logged while handling a Call.
+type CallNotificationService struct {
+	client *Client
+}
+
+func callNotificationPathPart(callSid string) string {
+	return callsPathPart + "/" + callSid + "/Notifications"
+}
+
+type CallNotification struct {
+	Sid         string     `json:"sid"`
+	AccountSid  string     `json:"account_sid"`
+	APIVersion  string     `json:"api_version"`
+	CallSid     string     `json:"call_sid"`
+	DateCreated TwilioTime `json:"date_created"`
+	DateUpdated TwilioTime `json:"date_updated"`
+	ErrorCode   Code       `json:"error_code"`
+	// Log is "0" for an error, and "1" for a warning.
+	Log           string     `json:"log"`
+	MessageDate   TwilioTime `json:"message_date"`
+	MessageText   string     `json:"message_text"`
+	MoreInfo      string     `json:"more_info"`
+	RequestMethod string     `json:"request_method"`
+	RequestURL    string     `json:"request_url"`
+	// The following fields are only populated by Get.
+	RequestVariables string `json:"request_variables"`
+	ResponseBody     string `json:"response_body"`
+	ResponseHeaders  string `json:"response_headers"`
+	URI              string `json:"uri"`
+}
+
+type CallNotificationPage struct {
+	Page
+	Notifications []*CallNotification `json:"notifications"`
+}
+
+// Get returns the CallNotification with the given sid.
+func (c *CallNotificationService) Get(ctx context.Context, callSid string, sid string) (*CallNotification, error) {
+	notification := new(CallNotification)
+	err := c.client.GetResource(ctx, callNotificationPathPart(callSid), sid, notification)
+	return notification, err
+}
+
+// GetPage returns a single Page of notifications for the Call, filtered by
+// data. To retrieve multiple pages, use GetPageIterator.
+func (c *CallNotificationService) GetPage(ctx context.Context, callSid string, data url.Values) (*CallNotificationPage, error) {
+	return c.GetPageIterator(callSid, data).Next(ctx)
+}
+
+type CallNotificationPageIterator struct {
+	p *PageIterator
+}
+
+// GetPageIterator returns a CallNotificationPageIterator with the given page
+// filters. Call iterator.Next() to get the first page of resources (and again
+// to retrieve subsequent pages).
+func (c *CallNotificationService) GetPageIterator(callSid string, data url.Values) *CallNotificationPageIterator {
+	iter := NewPageIterator(c.client, data, callNotificationPathPart(callSid))
+	return &CallNotificationPageIterator{
+		p: iter,
+	}
+}
+
+// Next returns the next page of resources. If there are no more resources,
+// NoMoreResults is returned.
+func (c *CallNotificationPageIterator) Next(ctx context.Context) (*CallNotificationPage, error) {
+	np := new(CallNotificationPage)
+	err := c.p.Next(ctx, np)
+	if err != nil {
+		return nil, err
+	}
+	c.p.SetNextPageURI(np.NextPageURI)
+	return np, nil
+}
@@ -13,7 +13,10 @@ import (
 const callsPathPart = "Calls"
 
 type CallService struct {
-	client *Client
+	client        *Client
+	Feedback      *CallFeedbackService
+	Notifications *CallNotificationService
+	Events        *CallRequestEventService
 }
 
 type Call struct {
@@ -136,6 +139,88 @@ func (c *CallService) MakeCall(from string, to string, u *url.URL) (*Call, error
 	return c.Create(context.Background(), data)
 }
 
+// CallParams are the parameters for starting a Call. For more information,
+// see https://www.twilio.com/docs/voice/api/call-resource#create-a-call-resource.
+type CallParams struct {
+	// To is an E.164 phone number like "+14105551234", or a client or SIP
+	// address like "client:alice".
+	To string
+	// From is an E.164 phone number, or a client address if To is one.
+	From string
+	// Exactly one of URL, Twiml and ApplicationSid must be set.
+	URL            string
+	Twiml          string
+	ApplicationSid string
+	// Method is the HTTP method used to request URL, "GET" or "POST".
+	Method               string
+	FallbackURL          string
+	FallbackMethod       string
+	StatusCallback       string
+	StatusCallbackMethod string
+	// StatusCallbackEvent lists the events that trigger a StatusCallback,
+	// e.g. "initiated", "ringing", "answered" and "completed".
+	StatusCallbackEvent []string
+	SendDigits          string
+	// Timeout is how many seconds to let the phone ring, between 5 and 600.
+	// Zero uses Twilio's default of 60.
+	Timeout          int
+	Record           bool
+	MachineDetection string
+}
+
+// Validate returns a *ValidationError if p is missing a required parameter,
+// has an invalid phone number, or sets more than one of URL, Twiml and
+// ApplicationSid.
+func (p *CallParams) Validate() error {
+	if err := validateAddress("To", p.To); err != nil {
+		return err
+	}
+	if err := validateAddress("From", p.From); err != nil {
+		return err
+	}
+	if err := exactlyOne([]string{"Url", "Twiml", "ApplicationSid"}, p.URL, p.Twiml, p.ApplicationSid); err != nil {
+		return err
+	}
+	if p.Timeout != 0 && (p.Timeout < 5 || p.Timeout > 600) {
+		return &ValidationError{Field: "Timeout", Message: "must be between 5 and 600 seconds"}
+	}
+	return nil
+}
+
+// Values returns the form values Twilio expects for p.
+func (p *CallParams) Values() url.Values {
+	v := url.Values{}
+	setString(v, "To", p.To)
+	setString(v, "From", p.From)
+	setString(v, "Url", p.URL)
+	setString(v, "Twiml", p.Twiml)
+	setString(v, "ApplicationSid", p.ApplicationSid)
+	setString(v, "Method", p.Method)
+	setString(v, "FallbackUrl", p.FallbackURL)
+	setString(v, "FallbackMethod", p.FallbackMethod)
+	setString(v, "StatusCallback", p.StatusCallback)
+	setString(v, "StatusCallbackMethod", p.StatusCallbackMethod)
+	for _, event := range p.StatusCallbackEvent {
+		v.Add("StatusCallbackEvent", event)
+	}
+	setString(v, "SendDigits", p.SendDigits)
+	setInt(v, "Timeout", p.Timeout)
+	if p.Record {
+		v.Set("Record", "true")
+	}
+	setString(v, "MachineDetection", p.MachineDetection)
+	return v
+}
+
+// CreateWithParams validates p and starts a Call. If p is invalid, it returns
+// a *ValidationError without making a request.
+func (c *CallService) CreateWithParams(ctx context.Context, p *CallParams) (*Call, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return c.Create(ctx, p.Values())
+}
+
 func (c *CallService) GetPage(ctx context.Context, data url.Values) (*CallPage, error) {
 	iter := c.GetPageIterator(data)
 	return iter.Next(ctx)
@@ -104,3 +104,64 @@ func TestCalls(t *testing.T) {
 		}
 	})
 }
+
+func TestCallFeedback(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(callFeedbackInstance)
+	defer server.Close()
+	sid := "CA47b862ce3b99a6d79939320a9aa54a02"
+	feedback, err := client.Calls.Feedback.Create(context.Background(), sid, 2, FeedbackIssueImperfectAudio, FeedbackIssuePostDialDelay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if feedback.QualityScore != 2 || len(feedback.Issues) != 2 || feedback.Issues[0] != FeedbackIssueImperfectAudio {
+		t.Errorf("bad feedback: %#v", feedback)
+	}
+	if _, err := client.Calls.Feedback.Get(context.Background(), sid); err != nil {
+		t.Fatal(err)
+	}
+	want := "/2010-04-01/Accounts/AC123/Calls/" + sid + "/Feedback.json"
+	for i, u := range server.URLs {
+		if u.String() != want {
+			t.Errorf("request %d URL:\ngot  %q\nwant %q", i, u, want)
+		}
+	}
+}
+
+func TestCallNotifications(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(callNotificationPage)
+	defer server.Close()
+	sid := "CA47b862ce3b99a6d79939320a9aa54a02"
+	page, err := client.Calls.Notifications.GetPage(context.Background(), sid, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Notifications) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(page.Notifications))
+	}
+	if n := page.Notifications[0]; n.ErrorCode != CodeHTTPRetrievalFailure || n.CallSid != sid {
+		t.Errorf("bad notification: %#v", n)
+	}
+	want := "/2010-04-01/Accounts/AC123/Calls/" + sid + "/Notifications.json"
+	if server.URLs[0].Path != want {
+		t.Errorf("request path:\ngot  %q\nwant %q", server.URLs[0].Path, want)
+	}
+}
+
+func TestCallRequestEvents(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(callRequestEventPage)
+	defer server.Close()
+	page, err := client.Calls.Events.GetPage(context.Background(), "CA47b862ce3b99a6d79939320a9aa54a02", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(page.Events))
+	}
+	ev := page.Events[0]
+	if ev.Request.Parameters["CallStatus"] != "ringing" || ev.Response.ResponseCode != 200 || ev.Response.RequestDuration != 121 {
+		t.Errorf("bad event: %#v", ev)
+	}
+}
@@ -0,0 +1,240 @@
+// Binary purge-messages redacts or deletes every message older than a number
+// of days, along with its media, to comply with a data retention policy.
+//
+// By default the body of each message is redacted, which keeps the message's
+// metadata (status, price, etc) for billing. Pass --delete to delete messages
+// instead.
+//
+//	$ purge-messages --days 90 --dry-run
+//	would redact SM0123456789abcdef0123456789abcdef (2017-06-19, 1 media)
+//	...
+//	$ purge-messages --days 90
+//	redacted 1204 messages and deleted 87 media
+//
+// Progress is saved to a checkpoint file after each page of messages. If
+// purge-messages is interrupted, run it again with the same --checkpoint to
+// resume where it stopped; the cutoff date is saved in the checkpoint, so
+// --days is ignored when resuming. The checkpoint is removed when every
+// message has been purged.
+//
+// Your Twilio credentials are loaded from the TWILIO_ACCOUNT_SID and
+// TWILIO_AUTH_TOKEN environment variables. There are several flags:
+//
+//	--days int
+//	    Purge messages sent more than this many days ago (required)
+//	--delete
+//	    Delete messages, instead of redacting their bodies
+//	--concurrency int
+//	    Number of messages to purge at once (default 4)
+//	--checkpoint string
+//	    File to save progress in (default "purge-messages.checkpoint")
+//	--dry-run
+//	    Print the messages that would be purged, without changing them
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"time"
+
+	twilio "github.com/kevinburke/twilio-go"
+	"golang.org/x/sync/errgroup"
+)
+
+var days = flag.Uint("days", 0, "Purge messages sent more than this many days ago (required)")
+var deleteMessages = flag.Bool("delete", false, "Delete messages, instead of redacting their bodies")
+var concurrency = flag.Int("concurrency", 4, "Number of messages to purge at once")
+var checkpointPath = flag.String("checkpoint", "purge-messages.checkpoint", "File to save progress in")
+var dryRun = flag.Bool("dry-run", false, "Print the messages that would be purged, without changing them")
+
+func init() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Redact or delete every message, and its media, older than --days.
+`)
+		flag.PrintDefaults()
+	}
+}
+
+// checkpoint records the progress of a purge, so it can be resumed.
+type checkpoint struct {
+	// Before is the cutoff; messages sent before it are purged.
+	Before time.Time `json:"before"`
+	// NextPageURI is the first page of messages that has not been purged.
+	NextPageURI string `json:"next_page_uri"`
+	Messages    int64  `json:"messages"`
+	Media       int64  `json:"media"`
+}
+
+func readCheckpoint(path string) (*checkpoint, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cp := new(checkpoint)
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("could not parse checkpoint %s: %v", path, err)
+	}
+	return cp, nil
+}
+
+// writeCheckpoint atomically replaces the checkpoint at path with cp.
+func writeCheckpoint(path string, cp *checkpoint) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+type purger struct {
+	client   *twilio.Client
+	delete   bool
+	dryRun   bool
+	messages int64
+	media    int64
+}
+
+// purge deletes the media of msg, then redacts or deletes msg.
+func (p *purger) purge(ctx context.Context, msg *twilio.Message) error {
+	verb := "redact"
+	if p.delete {
+		verb = "delete"
+	}
+	// NumMedia doesn't change when media is deleted, so list the media to see
+	// what's left, e.g. after an earlier run.
+	var media []*twilio.Media
+	if msg.NumMedia > 0 {
+		page, err := p.client.Media.GetPage(ctx, msg.Sid, url.Values{"PageSize": []string{"100"}})
+		if err != nil {
+			return fmt.Errorf("could not list media for %s: %v", msg.Sid, err)
+		}
+		media = page.MediaList
+	}
+	if !p.delete && msg.Body == "" && len(media) == 0 {
+		// Already redacted, e.g. by an earlier run.
+		return nil
+	}
+	date := msg.DateCreated.Time
+	if msg.DateSent.Valid {
+		date = msg.DateSent.Time
+	}
+	if p.dryRun {
+		fmt.Printf("would %s %s (%s, %d media)\n", verb, msg.Sid, date.Format("2006-01-02"), len(media))
+		atomic.AddInt64(&p.messages, 1)
+		atomic.AddInt64(&p.media, int64(len(media)))
+		return nil
+	}
+	for _, m := range media {
+		if err := p.client.Media.Delete(ctx, msg.Sid, m.Sid); err != nil {
+			return fmt.Errorf("could not delete media %s: %v", m.Sid, err)
+		}
+		atomic.AddInt64(&p.media, 1)
+	}
+	var err error
+	if p.delete {
+		err = p.client.Messages.Delete(ctx, msg.Sid)
+	} else if msg.Body != "" {
+		_, err = p.client.Messages.Redact(ctx, msg.Sid)
+	}
+	if err != nil {
+		return fmt.Errorf("could not %s %s: %v", verb, msg.Sid, err)
+	}
+	atomic.AddInt64(&p.messages, 1)
+	return nil
+}
+
+// purgePage purges every message in page, at most *concurrency at a time.
+func (p *purger) purgePage(ctx context.Context, page *twilio.MessagePage) error {
+	group, errctx := errgroup.WithContext(ctx)
+	sem := make(chan struct{}, *concurrency)
+	for _, msg := range page.Messages {
+		msg := msg
+		sem <- struct{}{}
+		group.Go(func() error {
+			defer func() { <-sem }()
+			return p.purge(errctx, msg)
+		})
+	}
+	return group.Wait()
+}
+
+func main() {
+	flag.Parse()
+	if *concurrency < 1 {
+		log.Fatal("--concurrency must be at least 1")
+	}
+	client := twilio.NewClient(os.Getenv("TWILIO_ACCOUNT_SID"), os.Getenv("TWILIO_AUTH_TOKEN"), nil)
+	client.SetRetryPolicy(twilio.DefaultRetryPolicy)
+	p := &purger{client: client, delete: *deleteMessages, dryRun: *dryRun}
+
+	cp, err := readCheckpoint(*checkpointPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var iter twilio.MessagePageIterator
+	if cp != nil && !*dryRun {
+		log.Printf("resuming from %s, purging messages sent before %s", *checkpointPath, cp.Before.Format(time.RFC3339))
+		p.messages, p.media = cp.Messages, cp.Media
+		iter = client.Messages.GetNextMessagesInRange(twilio.Epoch, cp.Before, cp.NextPageURI)
+	} else {
+		if *days == 0 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		cp = &checkpoint{Before: time.Now().UTC().Add(-time.Duration(*days) * 24 * time.Hour)}
+		iter = client.Messages.GetMessagesInRange(twilio.Epoch, cp.Before, nil)
+	}
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		page, err := iter.Next(ctx)
+		if err == nil {
+			err = p.purgePage(ctx, page)
+		}
+		cancel()
+		if err == twilio.NoMoreResults {
+			break
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !page.NextPageURI.Valid {
+			break
+		}
+		if *dryRun {
+			continue
+		}
+		cp.NextPageURI = page.NextPageURI.String
+		cp.Messages, cp.Media = atomic.LoadInt64(&p.messages), atomic.LoadInt64(&p.media)
+		if err := writeCheckpoint(*checkpointPath, cp); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	verb := "redacted"
+	if p.delete {
+		verb = "deleted"
+	}
+	if *dryRun {
+		fmt.Printf("would have %s %d messages and deleted %d media\n", verb, p.messages, p.media)
+		return
+	}
+	if err := os.Remove(*checkpointPath); err != nil && !os.IsNotExist(err) {
+		log.Fatal(err)
+	}
+	fmt.Printf("%s %d messages and deleted %d media\n", verb, p.messages, p.media)
+}
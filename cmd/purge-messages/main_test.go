@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	twilio "github.com/kevinburke/twilio-go"
+	"github.com/kevinburke/twilio-go/twiliotest"
+)
+
+// newTestServer returns a twiliotest.Server with a text message and a message
+// with two media.
+func newTestServer(t *testing.T) (*twiliotest.Server, *twilio.Client) {
+	t.Helper()
+	server := twiliotest.NewServer()
+	client := server.Client()
+	if _, err := client.Messages.SendMessage(twiliotest.NumberValid, "+14105551234", "hello", nil); err != nil {
+		t.Fatal(err)
+	}
+	media := []*url.URL{
+		{Scheme: "https", Host: "example.com", Path: "/cat.jpg"},
+		{Scheme: "https", Host: "example.com", Path: "/dog.jpg"},
+	}
+	if _, err := client.Messages.SendMessage(twiliotest.NumberValid, "+14105551234", "pets", media); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	return server, client
+}
+
+func purgeAll(t *testing.T, p *purger) {
+	t.Helper()
+	page, err := p.client.Messages.GetPage(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.purgePage(context.Background(), page); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func countMedia(t *testing.T, client *twilio.Client, msgs []*twilio.Message) int {
+	t.Helper()
+	n := 0
+	for _, msg := range msgs {
+		page, err := client.Media.GetPage(context.Background(), msg.Sid, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n += len(page.MediaList)
+	}
+	return n
+}
+
+func TestPurgeDryRun(t *testing.T) {
+	server, client := newTestServer(t)
+	defer server.Close()
+	p := &purger{client: client, dryRun: true}
+	purgeAll(t, p)
+	if p.messages != 2 || p.media != 2 {
+		t.Errorf("expected dry run to count 2 messages and 2 media, got %d and %d", p.messages, p.media)
+	}
+	msgs := server.Messages()
+	for _, msg := range msgs {
+		if msg.Body == "" {
+			t.Errorf("dry run redacted %s", msg.Sid)
+		}
+	}
+	if n := countMedia(t, client, msgs); n != 2 {
+		t.Errorf("dry run deleted media: %d left", n)
+	}
+}
+
+func TestPurgeRedact(t *testing.T) {
+	server, client := newTestServer(t)
+	defer server.Close()
+	p := &purger{client: client}
+	purgeAll(t, p)
+	if p.messages != 2 || p.media != 2 {
+		t.Errorf("expected to redact 2 messages and delete 2 media, got %d and %d", p.messages, p.media)
+	}
+	msgs := server.Messages()
+	if len(msgs) != 2 {
+		t.Fatalf("expected messages to be kept, got %d", len(msgs))
+	}
+	for _, msg := range msgs {
+		if msg.Body != "" {
+			t.Errorf("expected %s to be redacted, got %q", msg.Sid, msg.Body)
+		}
+	}
+	if n := countMedia(t, client, msgs); n != 0 {
+		t.Errorf("expected media to be deleted, %d left", n)
+	}
+
+	// NumMedia is unchanged after the media is deleted, but a second run
+	// has nothing left to purge.
+	again := &purger{client: client}
+	purgeAll(t, again)
+	if again.messages != 0 || again.media != 0 {
+		t.Errorf("expected a second run to purge nothing, got %d messages and %d media", again.messages, again.media)
+	}
+	dry := &purger{client: client, dryRun: true}
+	purgeAll(t, dry)
+	if dry.messages != 0 || dry.media != 0 {
+		t.Errorf("expected a dry run after purging to count nothing, got %d messages and %d media", dry.messages, dry.media)
+	}
+}
+
+func TestPurgeDelete(t *testing.T) {
+	server, client := newTestServer(t)
+	defer server.Close()
+	p := &purger{client: client, delete: true}
+	purgeAll(t, p)
+	if p.messages != 2 || p.media != 2 {
+		t.Errorf("expected to delete 2 messages and 2 media, got %d and %d", p.messages, p.media)
+	}
+	if msgs := server.Messages(); len(msgs) != 0 {
+		t.Errorf("expected messages to be deleted, got %d", len(msgs))
+	}
+}
@@ -37,6 +37,13 @@ func (c *Code) UnmarshalJSON(b []byte) error {
 	return c.convCode(i, err)
 }
 
+const CodeNotFound = 20404
+const CodeTooManyRequests = 20429
+const CodeInvalidToPhoneNumber = 21211
+const CodeInvalidFromPhoneNumber = 21212
+const CodeInvalidPhoneNumber = 21401
+const CodeUnsubscribedRecipient = 21610
+const CodeNotMobileNumber = 21614
 const CodeHTTPRetrievalFailure = 11200
 const CodeHTTPConnectionFailure = 11205
 const CodeHTTPProtocolViolation = 11206
@@ -12,7 +12,8 @@ import (
 const conferencePathPart = "Conferences"
 
 type ConferenceService struct {
-	client *Client
+	client       *Client
+	Participants *ParticipantService
 }
 
 type Conference struct {
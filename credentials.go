@@ -0,0 +1,184 @@
+package twilio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials are the username and password used to authenticate a request:
+// either an Account Sid and Auth Token, or an API Key Sid and its secret.
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// A CredentialProvider returns the Credentials for a request. A Client with a
+// CredentialProvider calls it before every request, including Media requests
+// and retries, so it can rotate credentials without restarting. Implementations
+// must be safe for concurrent use, and should be fast.
+type CredentialProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// StaticCredentials is a CredentialProvider that always returns the same
+// Credentials.
+type StaticCredentials Credentials
+
+// Credentials implements CredentialProvider.
+func (s StaticCredentials) Credentials(context.Context) (Credentials, error) {
+	return Credentials(s), nil
+}
+
+// APIKey returns a CredentialProvider that authenticates with an API Key. See
+// https://www.twilio.com/docs/iam/keys/api-key.
+func APIKey(keySid string, secret string) CredentialProvider {
+	return StaticCredentials{Username: keySid, Password: secret}
+}
+
+// EnvCredentials is a CredentialProvider that reads the TWILIO_API_KEY and
+// TWILIO_API_SECRET environment variables, or if those are not set,
+// TWILIO_ACCOUNT_SID and TWILIO_AUTH_TOKEN, for every request.
+type EnvCredentials struct{}
+
+// Credentials implements CredentialProvider.
+func (EnvCredentials) Credentials(context.Context) (Credentials, error) {
+	if key := os.Getenv("TWILIO_API_KEY"); key != "" {
+		return Credentials{Username: key, Password: os.Getenv("TWILIO_API_SECRET")}, nil
+	}
+	if sid := os.Getenv("TWILIO_ACCOUNT_SID"); sid != "" {
+		return Credentials{Username: sid, Password: os.Getenv("TWILIO_AUTH_TOKEN")}, nil
+	}
+	return Credentials{}, errors.New("twilio: neither TWILIO_API_KEY nor TWILIO_ACCOUNT_SID is set")
+}
+
+// A FileCredentials is a CredentialProvider that reads Credentials from a
+// JSON file, like
+//
+//	{"username": "SK123", "password": "secret"}
+//
+// The file is read again whenever its modification time changes, so a
+// secrets manager can rotate the credentials in place.
+type FileCredentials struct {
+	Path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	creds   Credentials
+}
+
+// NewFileCredentials returns a FileCredentials that reads from path.
+func NewFileCredentials(path string) *FileCredentials {
+	return &FileCredentials{Path: path}
+}
+
+// Credentials implements CredentialProvider.
+func (f *FileCredentials) Credentials(context.Context) (Credentials, error) {
+	fi, err := os.Stat(f.Path)
+	if err != nil {
+		return Credentials{}, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.creds.Username != "" && fi.ModTime().Equal(f.modTime) {
+		return f.creds, nil
+	}
+	data, err := ioutil.ReadFile(f.Path)
+	if err != nil {
+		return Credentials{}, err
+	}
+	var creds Credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return Credentials{}, fmt.Errorf("twilio: reading credentials from %s: %v", f.Path, err)
+	}
+	if creds.Username == "" {
+		return Credentials{}, fmt.Errorf("twilio: no username in %s", f.Path)
+	}
+	f.creds = creds
+	f.modTime = fi.ModTime()
+	return creds, nil
+}
+
+// A RotatingCredentials is a CredentialProvider whose Credentials can be
+// replaced while requests are in flight, for example after rotating an Auth
+// Token or API Key.
+type RotatingCredentials struct {
+	mu    sync.RWMutex
+	creds Credentials
+}
+
+// NewRotatingCredentials returns a RotatingCredentials that starts with
+// creds.
+func NewRotatingCredentials(creds Credentials) *RotatingCredentials {
+	return &RotatingCredentials{creds: creds}
+}
+
+// Set replaces the Credentials used for future requests.
+func (r *RotatingCredentials) Set(creds Credentials) {
+	r.mu.Lock()
+	r.creds = creds
+	r.mu.Unlock()
+}
+
+// Credentials implements CredentialProvider.
+func (r *RotatingCredentials) Credentials(context.Context) (Credentials, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.creds, nil
+}
+
+// SetCredentialProvider configures the Client, and every product Client
+// created by NewClient (Monitor, Pricing, Wireless, etc), to authenticate each
+// request with the Credentials from p, instead of the Account Sid and Auth
+// Token. Pass nil to go back to the Account Sid and Auth Token.
+//
+// SetCredentialProvider is not thread safe; call it before making any
+// requests. To change credentials while requests are in flight, use a
+// RotatingCredentials.
+func (c *Client) SetCredentialProvider(p CredentialProvider) {
+	c.CredentialProvider = p
+	c.forgetAccounts()
+	for _, sub := range c.subClients() {
+		sub.SetCredentialProvider(p)
+	}
+}
+
+// UseAPIKey authenticates every request made by the Client, and every product
+// Client, with the given API Key Sid and secret. The Account Sid is still
+// used in the URL of api.twilio.com resources.
+func (c *Client) UseAPIKey(keySid string, secret string) {
+	c.SetCredentialProvider(APIKey(keySid, secret))
+}
+
+// setAuth replaces the basic auth credentials of req with the Credentials
+// from c.CredentialProvider, if it is set. The product clients of a Client
+// returned by ForAccount authenticate as c.AccountSid instead of the
+// parent Account.
+func (c *Client) setAuth(ctx context.Context, req *http.Request) error {
+	if c.CredentialProvider == nil && !c.asAccount {
+		return nil
+	}
+	creds := Credentials{Username: c.Client.ID, Password: c.Client.Token}
+	if c.CredentialProvider != nil {
+		var err error
+		creds, err = c.CredentialProvider.Credentials(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	if c.asAccount {
+		if !strings.HasPrefix(creds.Username, "AC") {
+			return fmt.Errorf("twilio: can't make %s requests for subaccount %s with %s; use the subaccount's own credentials", req.URL.Host, c.AccountSid, creds.Username)
+		}
+		creds.Username = c.AccountSid
+	}
+	req.SetBasicAuth(creds.Username, creds.Password)
+	return nil
+}
@@ -0,0 +1,171 @@
+package twilio
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newAuthServer returns a server that records the basic auth username and
+// password of each request.
+func newAuthServer() (*httptest.Server, func() []Credentials) {
+	var mu sync.Mutex
+	var seen []Credentials
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		mu.Lock()
+		seen = append(seen, Credentials{Username: user, Password: pass})
+		mu.Unlock()
+		if strings.Contains(r.URL.Path, "/Media/") {
+			w.Header().Set("Location", "https://s3-external-1.amazonaws.com/media.twiliocdn.com/AC123/abc")
+			w.WriteHeader(302)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte("{}"))
+	}))
+	return s, func() []Credentials {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]Credentials(nil), seen...)
+	}
+}
+
+func TestUseAPIKey(t *testing.T) {
+	t.Parallel()
+	s, seen := newAuthServer()
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.UseAPIKey("SK123", "secret")
+	client.Base = s.URL
+	for _, sub := range client.subClients() {
+		if sub.CredentialProvider == nil {
+			t.Errorf("expected a CredentialProvider for %s", sub.Base)
+		}
+		sub.Base = s.URL
+	}
+	ctx := context.Background()
+	if _, err := client.Calls.Get(ctx, "CA123"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Verify.Verifications.Get(ctx, "VA123", "VE123"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Lookup.LookupPhoneNumbers.Get(ctx, "+14105551234", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Media.GetURL(ctx, "MM123", "ME123"); err != nil {
+		t.Fatal(err)
+	}
+	creds := seen()
+	if len(creds) != 4 {
+		t.Fatalf("expected 4 requests, got %d", len(creds))
+	}
+	for _, c := range creds {
+		if c.Username != "SK123" || c.Password != "secret" {
+			t.Errorf("expected API key credentials, got %q", c.Username)
+		}
+	}
+}
+
+func TestUseSecretKeyProductClients(t *testing.T) {
+	t.Parallel()
+	client := NewClient("AC123", "456", nil)
+	client.UseSecretKey("SK123")
+	for _, sub := range append([]*Client{client}, client.subClients()...) {
+		if sub.Client.ID != "SK123" {
+			t.Errorf("expected %s to use the secret key, got %s", sub.Base, sub.Client.ID)
+		}
+	}
+}
+
+func TestRotatingCredentials(t *testing.T) {
+	t.Parallel()
+	s, seen := newAuthServer()
+	defer s.Close()
+	rotating := NewRotatingCredentials(Credentials{Username: "AC123", Password: "old"})
+	client := NewClientWithOptions("AC123", "", WithBaseURL(ProductAPI, s.URL), WithCredentialProvider(rotating))
+	if _, err := client.Calls.Get(context.Background(), "CA123"); err != nil {
+		t.Fatal(err)
+	}
+	rotating.Set(Credentials{Username: "AC123", Password: "new"})
+	if _, err := client.Calls.Get(context.Background(), "CA123"); err != nil {
+		t.Fatal(err)
+	}
+	creds := seen()
+	if len(creds) != 2 || creds[0].Password != "old" || creds[1].Password != "new" {
+		t.Errorf("expected old then new token, got %v", creds)
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "twilio-credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "creds.json")
+	if err := ioutil.WriteFile(path, []byte(`{"username": "SK123", "password": "one"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f := NewFileCredentials(path)
+	creds, err := f.Credentials(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.Username != "SK123" || creds.Password != "one" {
+		t.Errorf("bad credentials: %v", creds)
+	}
+	if err := ioutil.WriteFile(path, []byte(`{"username": "SK456", "password": "two"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	creds, err = f.Credentials(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.Username != "SK456" || creds.Password != "two" {
+		t.Errorf("expected credentials to be reread, got %v", creds)
+	}
+	if _, err := NewFileCredentials(filepath.Join(dir, "missing.json")).Credentials(context.Background()); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+type errProvider struct{}
+
+var errNoCredentials = errors.New("no credentials")
+
+func (errProvider) Credentials(context.Context) (Credentials, error) {
+	return Credentials{}, errNoCredentials
+}
+
+func TestCredentialProviderError(t *testing.T) {
+	t.Parallel()
+	s, seen := newAuthServer()
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	client.SetCredentialProvider(errProvider{})
+	if _, err := client.Calls.Get(context.Background(), "CA123"); err != errNoCredentials {
+		t.Errorf("expected errNoCredentials, got %v", err)
+	}
+	if _, err := client.Media.GetURL(context.Background(), "MM123", "ME123"); err != errNoCredentials {
+		t.Errorf("expected errNoCredentials, got %v", err)
+	}
+	if n := len(seen()); n != 0 {
+		t.Errorf("expected no requests to the server, got %d", n)
+	}
+}
@@ -0,0 +1,179 @@
+package twilio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/kevinburke/rest/resterror"
+)
+
+// An Error is an error returned by the Twilio API, for example:
+//
+//	{"code": 21211, "message": "The 'To' number +1234 is not a valid phone number.",
+//	 "more_info": "https://www.twilio.com/docs/errors/21211", "status": 400}
+//
+// For backwards compatibility, the Client returns API errors as a
+// *resterror.Error. Use AsError to convert an error returned by the Client to
+// an *Error, or the IsNotFound, IsRateLimited, IsUnsubscribed and
+// IsInvalidPhoneNumber helpers to check for common failures. To receive *Error
+// values directly, including the raw Body, call SetErrorParser(ParseError).
+type Error struct {
+	// Code is the Twilio error code, e.g. 21211 for an invalid "To" number.
+	Code Code `json:"code"`
+	// Message is a human readable description of the error.
+	Message string `json:"message"`
+	// MoreInfo is a link to the documentation for the error code.
+	MoreInfo string `json:"more_info"`
+	// Status is the HTTP status code of the response. The "status" field in
+	// the response body is ignored in favor of the actual status code.
+	Status int `json:"status"`
+	// Body is the raw response body. It's only set on errors returned by
+	// ParseError.
+	Body []byte `json:"-"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is an *Error with the same Code as e. If target
+// has a zero Code, the Status is compared instead. On a Client configured
+// with SetErrorParser(ParseError), this lets you write
+//
+//	errors.Is(err, &twilio.Error{Code: twilio.CodeUnsubscribedRecipient})
+//
+// The default parser returns a *resterror.Error, which errors.Is can't match;
+// use AsError or a helper like IsUnsubscribed to check those.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if t.Code != 0 {
+		return e.Code == t.Code
+	}
+	return t.Status != 0 && e.Status == t.Status
+}
+
+// As lets errors.As convert an *Error into a *resterror.Error, the type
+// returned by earlier versions of this library.
+func (e *Error) As(target interface{}) bool {
+	rerr, ok := target.(**resterror.Error)
+	if !ok {
+		return false
+	}
+	*rerr = e.restError()
+	return true
+}
+
+func (e *Error) restError() *resterror.Error {
+	return &resterror.Error{
+		Title:  e.Message,
+		Type:   e.MoreInfo,
+		ID:     strconv.Itoa(int(e.Code)),
+		Status: e.Status,
+	}
+}
+
+// SetErrorParser configures the Client, and every product Client created by
+// NewClient (Monitor, Pricing, Wireless, etc), to parse API error responses
+// with parse. Pass ParseError to receive *Error values, or nil to restore the
+// default, which returns a *resterror.Error. Clients created with ForAccount
+// use the parser of their parent.
+//
+// SetErrorParser is not thread safe; call it before making any requests.
+func (c *Client) SetErrorParser(parse func(*http.Response) error) {
+	if parse == nil {
+		parse = parseTwilioError
+	}
+	c.ErrorParser = parse
+	c.forgetAccounts()
+	for _, sub := range c.subClients() {
+		sub.SetErrorParser(parse)
+	}
+}
+
+// ParseError parses a Twilio API error response into an *Error. It returns a
+// plain error if the body is not a valid Twilio error. ParseError closes the
+// response body.
+func ParseError(resp *http.Response) error {
+	resBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if err := resp.Body.Close(); err != nil {
+		return err
+	}
+	terr := new(Error)
+	err = json.Unmarshal(resBody, terr)
+	if err != nil {
+		return fmt.Errorf("invalid response body: %s", string(resBody))
+	}
+	if terr.Message == "" {
+		return fmt.Errorf("invalid response body: %s", string(resBody))
+	}
+	terr.Status = resp.StatusCode
+	terr.Body = resBody
+	return terr
+}
+
+// AsError returns the *Error in err's chain, converting a *resterror.Error
+// returned by the Client if necessary. The second return value is false if
+// err did not come from the Twilio API.
+func AsError(err error) (*Error, bool) {
+	var terr *Error
+	if errors.As(err, &terr) {
+		return terr, true
+	}
+	var rerr *resterror.Error
+	if !errors.As(err, &rerr) {
+		return nil, false
+	}
+	code, _ := strconv.Atoi(rerr.ID)
+	return &Error{
+		Code:     Code(code),
+		Message:  rerr.Title,
+		MoreInfo: rerr.Type,
+		Status:   rerr.Status,
+	}, true
+}
+
+// IsNotFound returns true if err is a Twilio API error indicating that the
+// requested resource does not exist.
+func IsNotFound(err error) bool {
+	terr, ok := AsError(err)
+	return ok && (terr.Status == http.StatusNotFound || terr.Code == CodeNotFound)
+}
+
+// IsRateLimited returns true if err is a Twilio API error indicating that too
+// many requests were made.
+func IsRateLimited(err error) bool {
+	terr, ok := AsError(err)
+	return ok && (terr.Status == http.StatusTooManyRequests || terr.Code == CodeTooManyRequests)
+}
+
+// IsUnsubscribed returns true if err indicates that the recipient replied
+// STOP, and can't be messaged until they opt back in.
+func IsUnsubscribed(err error) bool {
+	terr, ok := AsError(err)
+	return ok && terr.Code == CodeUnsubscribedRecipient
+}
+
+// IsInvalidPhoneNumber returns true if err indicates that a "To" or "From"
+// phone number could not be used because it is not a valid phone number.
+func IsInvalidPhoneNumber(err error) bool {
+	terr, ok := AsError(err)
+	if !ok {
+		return false
+	}
+	switch terr.Code {
+	case CodeInvalidToPhoneNumber, CodeInvalidFromPhoneNumber, CodeInvalidPhoneNumber, CodeNotMobileNumber:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,110 @@
+package twilio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kevinburke/rest/resterror"
+)
+
+var unsubscribedResp = []byte(`{"code": 21610, "message": "Attempt to send to unsubscribed recipient", "more_info": "https://www.twilio.com/docs/errors/21610", "status": 400}`)
+
+func TestAsError(t *testing.T) {
+	t.Parallel()
+	client := NewClient("", "", nil)
+	client.Base = errorServer.URL
+	_, err := client.Calls.Get(context.Background(), "unknown")
+	if _, ok := err.(*resterror.Error); !ok {
+		t.Fatalf("expected a *resterror.Error, got %#v", err)
+	}
+	terr, ok := AsError(fmt.Errorf("wrapped: %w", err))
+	if !ok {
+		t.Fatalf("could not convert %v to *Error", err)
+	}
+	if terr.Code != CodeNotFound {
+		t.Errorf("expected Code to be 20404, got %d", terr.Code)
+	}
+	if terr.Status != 404 {
+		t.Errorf("expected Status to be 404, got %d", terr.Status)
+	}
+	if terr.MoreInfo != "https://www.twilio.com/docs/errors/20404" {
+		t.Errorf("bad MoreInfo: %s", terr.MoreInfo)
+	}
+	if !IsNotFound(err) {
+		t.Error("expected IsNotFound to be true")
+	}
+	if IsRateLimited(err) || IsUnsubscribed(err) || IsInvalidPhoneNumber(err) {
+		t.Error("expected other predicates to be false")
+	}
+	if _, ok := AsError(errors.New("boom")); ok {
+		t.Error("expected plain error not to convert to *Error")
+	}
+}
+
+func TestParseError(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+		w.Write(unsubscribedResp)
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	client.SetErrorParser(ParseError)
+	_, err := client.Messages.SendMessage("+14105551234", "+14105556789", "hi", nil)
+	var terr *Error
+	if !errors.As(err, &terr) {
+		t.Fatalf("expected an *Error, got %#v", err)
+	}
+	if terr.Code != CodeUnsubscribedRecipient || terr.Status != 400 {
+		t.Errorf("bad error: %#v", terr)
+	}
+	if string(terr.Body) != string(unsubscribedResp) {
+		t.Errorf("expected Body to be the raw response, got %s", terr.Body)
+	}
+	if !IsUnsubscribed(err) {
+		t.Error("expected IsUnsubscribed to be true")
+	}
+	if !errors.Is(err, &Error{Code: CodeUnsubscribedRecipient}) {
+		t.Error("expected errors.Is to match on Code")
+	}
+	if errors.Is(err, &Error{Code: CodeInvalidToPhoneNumber}) {
+		t.Error("expected errors.Is not to match a different Code")
+	}
+	var rerr *resterror.Error
+	if !errors.As(err, &rerr) {
+		t.Fatal("expected errors.As to convert to *resterror.Error")
+	}
+	if rerr.ID != "21610" || rerr.Status != 400 {
+		t.Errorf("bad resterror.Error: %#v", rerr)
+	}
+}
+
+func TestSetErrorParser(t *testing.T) {
+	t.Parallel()
+	client := NewClient("AC123", "456", nil)
+	client.SetErrorParser(ParseError)
+	resp := &http.Response{StatusCode: 400, Body: ioutil.NopCloser(bytes.NewReader(unsubscribedResp))}
+	for _, sub := range append([]*Client{client}, client.subClients()...) {
+		resp.Body = ioutil.NopCloser(bytes.NewReader(unsubscribedResp))
+		if err := sub.ErrorParser(resp); !errors.Is(err, &Error{Code: CodeUnsubscribedRecipient}) {
+			t.Errorf("%s: expected an *Error, got %#v", sub.Base, err)
+		}
+	}
+	sub := client.ForAccount("AC456")
+	resp.Body = ioutil.NopCloser(bytes.NewReader(unsubscribedResp))
+	if _, ok := sub.Monitor.ErrorParser(resp).(*Error); !ok {
+		t.Error("expected ForAccount to inherit the error parser")
+	}
+	client.SetErrorParser(nil)
+	resp.Body = ioutil.NopCloser(bytes.NewReader(unsubscribedResp))
+	if _, ok := client.Lookup.ErrorParser(resp).(*resterror.Error); !ok {
+		t.Error("expected nil to restore the default parser")
+	}
+}
@@ -3,6 +3,7 @@ package twilio
 import (
 	"context"
 	"net/url"
+	"strings"
 )
 
 const faxPathPart = "Faxes"
@@ -102,6 +103,64 @@ func (f *FaxService) SendFax(from string, to string, mediaURL *url.URL) (*Fax, e
 	return f.Create(context.Background(), v)
 }
 
+// FaxParams are the parameters for sending a Fax. For more information, see
+// https://www.twilio.com/docs/fax/api/fax-resource#create-a-fax-resource.
+type FaxParams struct {
+	// To is an E.164 phone number, or a SIP address like
+	// "sip:fax@example.com".
+	To   string
+	From string
+	// MediaURL is the URL of the PDF to send. It's required.
+	MediaURL string
+	// Quality is "standard", "fine" or "superfine".
+	Quality        string
+	StatusCallback string
+	// StoreMedia controls whether Twilio keeps a copy of the media. Nil uses
+	// Twilio's default, which is true.
+	StoreMedia *bool
+	// TTL is how many minutes to try sending the fax for.
+	TTL int
+}
+
+// Validate returns a *ValidationError if p is missing a required parameter or
+// has an invalid phone number.
+func (p *FaxParams) Validate() error {
+	if err := validateAddress("To", p.To); err != nil {
+		return err
+	}
+	if p.From != "" && !strings.HasPrefix(p.From, "sip:") {
+		if err := validatePhoneNumber("From", p.From); err != nil {
+			return err
+		}
+	}
+	if p.MediaURL == "" {
+		return &ValidationError{Field: "MediaUrl", Message: "a media URL is required"}
+	}
+	return nil
+}
+
+// Values returns the form values Twilio expects for p.
+func (p *FaxParams) Values() url.Values {
+	v := url.Values{}
+	setString(v, "To", p.To)
+	setString(v, "From", p.From)
+	setString(v, "MediaUrl", p.MediaURL)
+	setString(v, "Quality", p.Quality)
+	setString(v, "StatusCallback", p.StatusCallback)
+	setBool(v, "StoreMedia", p.StoreMedia)
+	setInt(v, "Ttl", p.TTL)
+	return v
+}
+
+// CreateWithParams validates p and sends a Fax. If p is invalid, it returns a
+// *ValidationError without making a request.
+func (f *FaxService) CreateWithParams(ctx context.Context, p *FaxParams) (*Fax, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return f.Create(ctx, p.Values())
+}
+
 // FaxPageIterator lets you retrieve consecutive pages of resources.
 type FaxPageIterator interface {
 	// Next returns the next page of resources. If there are no more resources,
@@ -3,16 +3,17 @@ package twilio
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
-	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/kevinburke/rest/restclient"
-	"github.com/kevinburke/rest/resterror"
 )
 
 // The twilio-go version. Run "make release" to bump this number.
@@ -48,11 +49,13 @@ const WirelessVersion = "v1"
 // APIVersion; the resource representations may not match.
 const APIVersion = "2010-04-01"
 
-const NotifyBaseURL = "https://notify.twilio.com"
+var NotifyBaseURL = "https://notify.twilio.com"
+
 const NotifyVersion = "v1"
 
 // Lookup service
-const LookupBaseURL = "https://lookups.twilio.com"
+var LookupBaseURL = "https://lookups.twilio.com"
+
 const LookupVersion = "v1"
 
 // Super sim service
@@ -60,7 +63,8 @@ var SuperSimBaseUrl = "https://supersim.twilio.com"
 var SuperSimVersion = "v1"
 
 // Verify service
-const VerifyBaseURL = "https://verify.twilio.com"
+var VerifyBaseURL = "https://verify.twilio.com"
+
 const VerifyVersion = "v2"
 
 // Video service
@@ -77,6 +81,12 @@ var InsightsBaseUrl = "https://insights.twilio.com"
 
 const InsightsVersion = "v1"
 
+// The base URL for Twilio Messaging Services.
+var MessagingBaseURL = "https://messaging.twilio.com"
+
+// Version of the Twilio Messaging API.
+const MessagingVersion = "v1"
+
 type Client struct {
 	*restclient.Client
 	Monitor    *Client
@@ -90,6 +100,10 @@ type Client struct {
 	TaskRouter *Client
 	Insights   *Client
 	SuperSim   *Client
+	// MessagingServices is the client for the Messaging API at
+	// messaging.twilio.com/v1. (Messaging is the Pricing API's service for
+	// message prices.)
+	MessagingServices *Client
 
 	// FullPath takes a path part (e.g. "Messages") and
 	// returns the full API path, including the version (e.g.
@@ -101,6 +115,33 @@ type Client struct {
 	AccountSid string
 	AuthToken  string
 
+	// RetryPolicy controls whether failed requests are retried. A nil
+	// RetryPolicy disables retries. Use SetRetryPolicy to configure the
+	// product clients as well.
+	RetryPolicy *RetryPolicy
+
+	// CredentialProvider, if non-nil, supplies the credentials for each
+	// request, instead of the AccountSid and AuthToken. Use
+	// SetCredentialProvider to configure the product clients as well.
+	CredentialProvider CredentialProvider
+
+	// Logger, if non-nil, logs each request. Use SetLogger to configure the
+	// product clients as well.
+	Logger *slog.Logger
+
+	// Limiter, if non-nil, is waited on before each request. Use SetLimiter
+	// to configure the product clients as well.
+	Limiter Limiter
+
+	middleware      []Middleware
+	userAgentSuffix string
+
+	// accounts caches the Clients returned by ForAccount, by subaccount Sid.
+	accounts *sync.Map
+	// asAccount is set on the product clients of a Client returned by
+	// ForAccount; they authenticate as AccountSid. See setAuth.
+	asAccount bool
+
 	// The API Client uses these resources
 	Accounts          *AccountService
 	Applications      *ApplicationService
@@ -115,6 +156,8 @@ type Client struct {
 	Recordings        *RecordingService
 	Transcriptions    *TranscriptionService
 	AvailableNumbers  *AvailableNumberService
+	UsageRecords      *UsageRecordService
+	UsageTriggers     *UsageTriggerService
 
 	// NewMonitorClient initializes these services
 	Alerts *AlertService
@@ -153,6 +196,9 @@ type Client struct {
 
 	// NewInsightsClient initializes these services
 	VoiceInsights func(sid string) *VoiceInsightsService
+
+	// NewMessagingClient initializes these services
+	Services *MessagingServiceService
 }
 
 const defaultTimeout = 30*time.Second + 500*time.Millisecond
@@ -166,57 +212,23 @@ func init() {
 	}
 }
 
-// An error returned by the Twilio API. We don't want to expose this - let's
-// try to standardize on the fields in the HTTP problem spec instead.
-type twilioError struct {
-	Code     int    `json:"code"`
-	Message  string `json:"message"`
-	MoreInfo string `json:"more_info"`
-	// This will be ignored in favor of the actual HTTP status code
-	Status int `json:"status"`
-}
-
+// parseTwilioError parses the error response into a *resterror.Error, the
+// type this library has always returned for API errors.
 func parseTwilioError(resp *http.Response) error {
-	resBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	if err := resp.Body.Close(); err != nil {
-		return err
-	}
-	rerr := new(twilioError)
-	err = json.Unmarshal(resBody, rerr)
-	if err != nil {
-		return fmt.Errorf("invalid response body: %s", string(resBody))
-	}
-	if rerr.Message == "" {
-		return fmt.Errorf("invalid response body: %s", string(resBody))
-	}
-	return &resterror.Error{
-		Title:  rerr.Message,
-		Type:   rerr.MoreInfo,
-		ID:     strconv.Itoa(rerr.Code),
-		Status: resp.StatusCode,
+	err := ParseError(resp)
+	if terr, ok := err.(*Error); ok {
+		return terr.restError()
 	}
+	return err
 }
 
 // NewFaxClient returns a Client for use with the Twilio Fax API.
 func NewFaxClient(accountSid string, authToken string, httpClient *http.Client) *Client {
-	if httpClient == nil {
-		httpClient = defaultHttpClient
-	}
-	restClient := restclient.New(accountSid, authToken, FaxBaseURL)
-	restClient.Client = httpClient
-	restClient.UploadType = restclient.FormURLEncoded
-	restClient.ErrorParser = parseTwilioError
-	c := &Client{
-		Client:     restClient,
-		AccountSid: accountSid,
-		AuthToken:  authToken,
-	}
-	c.FullPath = func(pathPart string) string {
-		return "/" + c.APIVersion + "/" + pathPart
-	}
+	return newFaxClient(accountSid, authToken, FaxBaseURL, httpClient)
+}
+
+func newFaxClient(accountSid string, authToken string, baseURL string, httpClient *http.Client) *Client {
+	c := newNewClient(accountSid, authToken, baseURL, httpClient)
 	c.APIVersion = FaxVersion
 	c.Faxes = &FaxService{client: c}
 	return c
@@ -243,7 +255,11 @@ func newNewClient(sid, token, baseURL string, client *http.Client) *Client {
 
 // NewWirelessClient returns a Client for use with the Twilio Wireless API.
 func NewWirelessClient(accountSid string, authToken string, httpClient *http.Client) *Client {
-	c := newNewClient(accountSid, authToken, WirelessBaseURL, httpClient)
+	return newWirelessClient(accountSid, authToken, WirelessBaseURL, httpClient)
+}
+
+func newWirelessClient(accountSid string, authToken string, baseURL string, httpClient *http.Client) *Client {
+	c := newNewClient(accountSid, authToken, baseURL, httpClient)
 	c.APIVersion = WirelessVersion
 	c.Sims = &SimService{client: c}
 	c.Commands = &CommandService{client: c}
@@ -252,7 +268,11 @@ func NewWirelessClient(accountSid string, authToken string, httpClient *http.Cli
 
 // NewSuperSimClient returns a Client for use with the Twilio SuperSim API.
 func NewSuperSimClient(accountSid string, authToken string, httpClient *http.Client) *Client {
-	c := newNewClient(accountSid, authToken, SuperSimBaseUrl, httpClient)
+	return newSuperSimClient(accountSid, authToken, SuperSimBaseUrl, httpClient)
+}
+
+func newSuperSimClient(accountSid string, authToken string, baseURL string, httpClient *http.Client) *Client {
+	c := newNewClient(accountSid, authToken, baseURL, httpClient)
 	c.APIVersion = SuperSimVersion
 	c.SuperSims = &SuperSimService{client: c}
 	c.Networks = &NetworkService{client: c}
@@ -261,7 +281,11 @@ func NewSuperSimClient(accountSid string, authToken string, httpClient *http.Cli
 
 // NewMonitorClient returns a Client for use with the Twilio Monitor API.
 func NewMonitorClient(accountSid string, authToken string, httpClient *http.Client) *Client {
-	c := newNewClient(accountSid, authToken, MonitorBaseURL, httpClient)
+	return newMonitorClient(accountSid, authToken, MonitorBaseURL, httpClient)
+}
+
+func newMonitorClient(accountSid string, authToken string, baseURL string, httpClient *http.Client) *Client {
+	c := newNewClient(accountSid, authToken, baseURL, httpClient)
 	c.APIVersion = MonitorVersion
 	c.Alerts = &AlertService{client: c}
 	return c
@@ -272,7 +296,11 @@ func NewTaskRouterClient(accountSid string, authToken string, httpClient *http.C
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: defaultTimeout}
 	}
-	c := newNewClient(accountSid, authToken, TaskRouterBaseUrl, httpClient)
+	return newTaskRouterClient(accountSid, authToken, TaskRouterBaseUrl, httpClient)
+}
+
+func newTaskRouterClient(accountSid string, authToken string, baseURL string, httpClient *http.Client) *Client {
+	c := newNewClient(accountSid, authToken, baseURL, httpClient)
 	c.APIVersion = TaskRouterVersion
 	c.Workspace = func(sid string) *WorkspaceService {
 		return &WorkspaceService{
@@ -298,7 +326,11 @@ func NewTaskRouterClient(accountSid string, authToken string, httpClient *http.C
 }
 
 func NewInsightsClient(accountSid string, authToken string, httpClient *http.Client) *Client {
-	c := newNewClient(accountSid, authToken, InsightsBaseUrl, httpClient)
+	return newInsightsClient(accountSid, authToken, InsightsBaseUrl, httpClient)
+}
+
+func newInsightsClient(accountSid string, authToken string, baseURL string, httpClient *http.Client) *Client {
+	c := newNewClient(accountSid, authToken, baseURL, httpClient)
 	c.APIVersion = InsightsVersion
 	c.VoiceInsights = func(callSid string) *VoiceInsightsService {
 		return &VoiceInsightsService{
@@ -319,9 +351,26 @@ func NewInsightsClient(accountSid string, authToken string, httpClient *http.Cli
 	return c
 }
 
+// NewMessagingClient returns a Client for use with the Twilio Messaging API,
+// which manages Messaging Services and their senders.
+func NewMessagingClient(accountSid string, authToken string, httpClient *http.Client) *Client {
+	return newMessagingClient(accountSid, authToken, MessagingBaseURL, httpClient)
+}
+
+func newMessagingClient(accountSid string, authToken string, baseURL string, httpClient *http.Client) *Client {
+	c := newNewClient(accountSid, authToken, baseURL, httpClient)
+	c.APIVersion = MessagingVersion
+	c.Services = &MessagingServiceService{client: c}
+	return c
+}
+
 // NewPricingClient returns a new Client to use the pricing API
 func NewPricingClient(accountSid string, authToken string, httpClient *http.Client) *Client {
-	c := newNewClient(accountSid, authToken, PricingBaseURL, httpClient)
+	return newPricingClient(accountSid, authToken, PricingBaseURL, httpClient)
+}
+
+func newPricingClient(accountSid string, authToken string, baseURL string, httpClient *http.Client) *Client {
+	c := newNewClient(accountSid, authToken, baseURL, httpClient)
 	c.APIVersion = PricingVersion
 	c.Voice = &VoicePriceService{
 		Countries: &CountryVoicePriceService{client: c},
@@ -337,7 +386,11 @@ func NewPricingClient(accountSid string, authToken string, httpClient *http.Clie
 }
 
 func NewNotifyClient(accountSid string, authToken string, httpClient *http.Client) *Client {
-	c := newNewClient(accountSid, authToken, NotifyBaseURL, httpClient)
+	return newNotifyClient(accountSid, authToken, NotifyBaseURL, httpClient)
+}
+
+func newNotifyClient(accountSid string, authToken string, baseURL string, httpClient *http.Client) *Client {
+	c := newNewClient(accountSid, authToken, baseURL, httpClient)
 	c.APIVersion = NotifyVersion
 	c.Credentials = &NotifyCredentialsService{client: c}
 	return c
@@ -345,7 +398,11 @@ func NewNotifyClient(accountSid string, authToken string, httpClient *http.Clien
 
 // NewLookupClient returns a new Client to use the lookups API
 func NewLookupClient(accountSid string, authToken string, httpClient *http.Client) *Client {
-	c := newNewClient(accountSid, authToken, LookupBaseURL, httpClient)
+	return newLookupClient(accountSid, authToken, LookupBaseURL, httpClient)
+}
+
+func newLookupClient(accountSid string, authToken string, baseURL string, httpClient *http.Client) *Client {
+	c := newNewClient(accountSid, authToken, baseURL, httpClient)
 	c.APIVersion = LookupVersion
 	c.LookupPhoneNumbers = &LookupPhoneNumbersService{client: c}
 	return c
@@ -353,7 +410,11 @@ func NewLookupClient(accountSid string, authToken string, httpClient *http.Clien
 
 // NewVerifyClient returns a new Client to use the verify API
 func NewVerifyClient(accountSid string, authToken string, httpClient *http.Client) *Client {
-	c := newNewClient(accountSid, authToken, VerifyBaseURL, httpClient)
+	return newVerifyClient(accountSid, authToken, VerifyBaseURL, httpClient)
+}
+
+func newVerifyClient(accountSid string, authToken string, baseURL string, httpClient *http.Client) *Client {
+	c := newNewClient(accountSid, authToken, baseURL, httpClient)
 	c.APIVersion = VerifyVersion
 	c.Verifications = &VerifyPhoneNumberService{client: c}
 	return c
@@ -361,7 +422,11 @@ func NewVerifyClient(accountSid string, authToken string, httpClient *http.Clien
 
 // NewVideoClient returns a new Client to use the video API
 func NewVideoClient(accountSid string, authToken string, httpClient *http.Client) *Client {
-	c := newNewClient(accountSid, authToken, VideoBaseUrl, httpClient)
+	return newVideoClient(accountSid, authToken, VideoBaseUrl, httpClient)
+}
+
+func newVideoClient(accountSid string, authToken string, baseURL string, httpClient *http.Client) *Client {
+	c := newNewClient(accountSid, authToken, baseURL, httpClient)
 	c.APIVersion = VideoVersion
 	c.Rooms = &RoomService{client: c}
 	c.VideoRecordings = &VideoRecordingService{client: c}
@@ -372,43 +437,63 @@ func NewVideoClient(accountSid string, authToken string, httpClient *http.Client
 // main entrypoint for API interactions; view the methods on the subresources
 // for more information.
 func NewClient(accountSid string, authToken string, httpClient *http.Client) *Client {
+	return newClient(accountSid, authToken, httpClient, defaultBaseURL)
+}
+
+// newClient creates a Client, and its product clients, using baseURL to find
+// the base URL for each product.
+func newClient(accountSid string, authToken string, httpClient *http.Client, baseURL func(Product) string) *Client {
 	if httpClient == nil {
 		httpClient = defaultHttpClient
 	}
-	restClient := restclient.New(accountSid, authToken, BaseURL)
+	restClient := restclient.New(accountSid, authToken, baseURL(ProductAPI))
 	restClient.Client = httpClient
 	restClient.UploadType = restclient.FormURLEncoded
 	restClient.ErrorParser = parseTwilioError
 
-	c := &Client{Client: restClient, AccountSid: accountSid, AuthToken: authToken}
+	c := &Client{Client: restClient, AccountSid: accountSid, AuthToken: authToken, accounts: new(sync.Map)}
 	c.APIVersion = APIVersion
 
 	c.FullPath = func(pathPart string) string {
 		return "/" + strings.Join([]string{c.APIVersion, "Accounts", c.AccountSid, pathPart + ".json"}, "/")
 	}
-	c.Monitor = NewMonitorClient(accountSid, authToken, httpClient)
-	c.Pricing = NewPricingClient(accountSid, authToken, httpClient)
-	c.Fax = NewFaxClient(accountSid, authToken, httpClient)
-	c.Wireless = NewWirelessClient(accountSid, authToken, httpClient)
-	c.Notify = NewNotifyClient(accountSid, authToken, httpClient)
-	c.Lookup = NewLookupClient(accountSid, authToken, httpClient)
-	c.Verify = NewVerifyClient(accountSid, authToken, httpClient)
-	c.Video = NewVideoClient(accountSid, authToken, httpClient)
-	c.TaskRouter = NewTaskRouterClient(accountSid, authToken, httpClient)
-	c.Insights = NewInsightsClient(accountSid, authToken, httpClient)
-	c.SuperSim = NewSuperSimClient(accountSid, authToken, httpClient)
+	c.Monitor = newMonitorClient(accountSid, authToken, baseURL(ProductMonitor), httpClient)
+	c.Pricing = newPricingClient(accountSid, authToken, baseURL(ProductPricing), httpClient)
+	c.Fax = newFaxClient(accountSid, authToken, baseURL(ProductFax), httpClient)
+	c.Wireless = newWirelessClient(accountSid, authToken, baseURL(ProductWireless), httpClient)
+	c.Notify = newNotifyClient(accountSid, authToken, baseURL(ProductNotify), httpClient)
+	c.Lookup = newLookupClient(accountSid, authToken, baseURL(ProductLookup), httpClient)
+	c.Verify = newVerifyClient(accountSid, authToken, baseURL(ProductVerify), httpClient)
+	c.Video = newVideoClient(accountSid, authToken, baseURL(ProductVideo), httpClient)
+	c.TaskRouter = newTaskRouterClient(accountSid, authToken, baseURL(ProductTaskRouter), httpClient)
+	c.Insights = newInsightsClient(accountSid, authToken, baseURL(ProductInsights), httpClient)
+	c.SuperSim = newSuperSimClient(accountSid, authToken, baseURL(ProductSuperSim), httpClient)
+	c.MessagingServices = newMessagingClient(accountSid, authToken, baseURL(ProductMessaging), httpClient)
 
 	c.Accounts = &AccountService{client: c}
 	c.Applications = &ApplicationService{client: c}
-	c.Calls = &CallService{client: c}
-	c.Conferences = &ConferenceService{client: c}
+	c.Calls = &CallService{
+		client:        c,
+		Feedback:      &CallFeedbackService{client: c},
+		Notifications: &CallNotificationService{client: c},
+		Events:        &CallRequestEventService{client: c},
+	}
+	c.Conferences = &ConferenceService{
+		client:       c,
+		Participants: &ParticipantService{client: c},
+	}
 	c.Keys = &KeyService{client: c}
 	c.Media = &MediaService{client: c}
 	c.Messages = &MessageService{client: c}
 	c.OutgoingCallerIDs = &OutgoingCallerIDService{client: c}
-	c.Queues = &QueueService{client: c}
+	c.Queues = &QueueService{
+		client:  c,
+		Members: &QueueMemberService{client: c},
+	}
 	c.Recordings = &RecordingService{client: c}
 	c.Transcriptions = &TranscriptionService{client: c}
+	c.UsageRecords = &UsageRecordService{client: c}
+	c.UsageTriggers = &UsageTriggerService{client: c}
 
 	c.IncomingNumbers = &IncomingNumberService{
 		NumberPurchasingService: &NumberPurchasingService{
@@ -461,33 +546,103 @@ func NewClient(accountSid string, authToken string, httpClient *http.Client) *Cl
 //
 // To authenticate using a subaccount sid / auth token, create a new Client
 // using that account's credentials.
+//
+// ForAccount is a thread safe alternative that also works with the product
+// clients.
 func (c *Client) RequestOnBehalfOf(subaccountSid string) {
 	c.FullPath = func(pathPart string) string {
 		return "/" + strings.Join([]string{c.APIVersion, "Accounts", subaccountSid, pathPart + ".json"}, "/")
 	}
 }
 
+// ForAccount returns a Client that makes requests for the given subaccount,
+// using c's credentials. c is not modified, so ForAccount is safe to call from
+// multiple goroutines, and the returned Client can be used alongside c.
+// Repeated calls with the same subaccountSid return the same Client.
+//
+// The returned Client puts subaccountSid in the URL of api.twilio.com
+// resources (Messages, Calls, IncomingNumbers, etc). Its product clients
+// (Monitor, Wireless, etc) don't have an Account in the URL, and act on the
+// Account they authenticate as, so they authenticate as subaccountSid with
+// c's Auth Token, which Twilio accepts for subaccounts of the Auth Token's
+// Account. This also applies to Credentials from a CredentialProvider. An API
+// key can't act on a subaccount, so if c uses one (see UseAPIKey and
+// UseSecretKey), product requests fail with an error instead of acting on
+// c's Account; create a Client with the subaccount's own credentials instead.
+//
+// The returned Client shares c's http.Client, base URLs, middleware, retry
+// policy, limiter, logger and error parser. Changing them on c afterwards
+// with SetRetryPolicy, Use, etc. does not affect it, but the next call to
+// ForAccount returns a new Client with the new settings. Setting c's fields
+// directly does not; set them before calling ForAccount.
+func (c *Client) ForAccount(subaccountSid string) *Client {
+	if c.accounts == nil {
+		return c.newAccountClient(subaccountSid)
+	}
+	if a, ok := c.accounts.Load(subaccountSid); ok {
+		return a.(*Client)
+	}
+	a, _ := c.accounts.LoadOrStore(subaccountSid, c.newAccountClient(subaccountSid))
+	return a.(*Client)
+}
+
+func (c *Client) newAccountClient(subaccountSid string) *Client {
+	baseURL := func(p Product) string {
+		if p == ProductAPI {
+			return c.Base
+		}
+		if sub := c.productClient(p); sub != nil {
+			return sub.Base
+		}
+		return defaultBaseURL(p)
+	}
+	a := newClient(subaccountSid, c.AuthToken, c.Client.Client, baseURL)
+	a.inherit(c, c.Client.ID)
+	for _, sub := range a.subClients() {
+		sub.inherit(c, c.Client.ID)
+		sub.asAccount = true
+	}
+	return a
+}
+
+// forgetAccounts drops the Clients cached by ForAccount, so the next call
+// picks up a change to c's settings.
+func (c *Client) forgetAccounts() {
+	if c.accounts == nil {
+		return
+	}
+	c.accounts.Range(func(sid, _ interface{}) bool {
+		c.accounts.Delete(sid)
+		return true
+	})
+}
+
+// inherit copies the credentials and request settings of parent to c, and
+// sets c's basic auth username to id.
+func (c *Client) inherit(parent *Client, id string) {
+	c.Client.ID = id
+	c.Client.Token = parent.Client.Token
+	c.ErrorParser = parent.ErrorParser
+	c.RetryPolicy = parent.RetryPolicy
+	c.Limiter = parent.Limiter
+	c.CredentialProvider = parent.CredentialProvider
+	c.Logger = parent.Logger
+	// Force a copy if either Client appends more middleware.
+	c.middleware = parent.middleware[:len(parent.middleware):len(parent.middleware)]
+	c.userAgentSuffix = parent.userAgentSuffix
+}
+
 // UseSecretKey will use the provided secret key to authenticate to the API
-// (instead of the AccountSid).
+// (instead of the AccountSid), on the Client and every product Client.
 //
 // For more information about secret keys, see
-// https://www.twilio.com/docs/api/rest/keys.
+// https://www.twilio.com/docs/api/rest/keys. To authenticate with an API Key
+// Sid and secret, use UseAPIKey.
 func (c *Client) UseSecretKey(key string) {
 	c.Client.ID = key
-	if c.Monitor != nil {
-		c.Monitor.UseSecretKey(key)
-	}
-	if c.Pricing != nil {
-		c.Pricing.UseSecretKey(key)
-	}
-	if c.Fax != nil {
-		c.Fax.UseSecretKey(key)
-	}
-	if c.Wireless != nil {
-		c.Wireless.UseSecretKey(key)
-	}
-	if c.Insights != nil {
-		c.Insights.UseSecretKey(key)
+	c.forgetAccounts()
+	for _, sub := range c.subClients() {
+		sub.UseSecretKey(key)
 	}
 }
 
@@ -498,7 +653,9 @@ func (c *Client) GetResource(ctx context.Context, pathPart string, sid string, v
 	return c.MakeRequest(ctx, "GET", sidPart, nil, v)
 }
 
-// CreateResource makes a POST request to the given resource.
+// CreateResource makes a POST request to the given resource. Once it has been
+// sent, the request is not retried, even with a Context from
+// WithIdempotencyKey.
 func (c *Client) CreateResource(ctx context.Context, pathPart string, data url.Values, v interface{}) error {
 	return c.MakeRequest(ctx, "POST", pathPart, data, v)
 }
@@ -514,8 +671,7 @@ func (c *Client) DeleteResource(ctx context.Context, pathPart string, sid string
 	if err == nil {
 		return nil
 	}
-	rerr, ok := err.(*resterror.Error)
-	if ok && rerr.Status == http.StatusNotFound {
+	if terr, ok := AsError(err); ok && terr.Status == http.StatusNotFound {
 		return nil
 	}
 	return err
@@ -534,27 +690,151 @@ func (c *Client) GetNextPage(ctx context.Context, fullUri string, v interface{})
 	return c.MakeRequest(ctx, "GET", fullUri, nil, v)
 }
 
-// Make a request to the Twilio API.
+// Make a request to the Twilio API. If c.RetryPolicy is set, requests that
+// fail with a transient error are retried; see RetryPolicy for details. Before
+// each attempt, MakeRequest waits for c.Limiter, if it is set, and then sends
+// the request through the middleware added with Use.
 func (c *Client) MakeRequest(ctx context.Context, method string, pathPart string, data url.Values, v interface{}) error {
 	if !strings.HasPrefix(pathPart, "/"+c.APIVersion) {
 		pathPart = c.FullPath(pathPart)
 	}
-	rb := new(strings.Reader)
+	var body string
 	if data != nil && (method == "POST" || method == "PUT") {
-		rb = strings.NewReader(data.Encode())
+		body = data.Encode()
 	}
 	if method == "GET" && data != nil {
 		pathPart = pathPart + "?" + data.Encode()
 	}
-	req, err := c.NewRequest(method, pathPart, rb)
+	var key string
+	if method == "POST" {
+		// The same key is sent with every attempt.
+		key = IdempotencyKey(ctx)
+	}
+	for attempt := 1; ; attempt++ {
+		req, err := c.NewRequest(method, pathPart, strings.NewReader(body))
+		if err != nil {
+			return err
+		}
+		// WroteRequest may be called from the transport's goroutine.
+		var sent int32
+		trace := &httptrace.ClientTrace{
+			WroteRequest: func(httptrace.WroteRequestInfo) { atomic.StoreInt32(&sent, 1) },
+		}
+		req = withContext(req, httptrace.WithClientTrace(ctx, trace))
+		if err := c.setAuth(ctx, req); err != nil {
+			return err
+		}
+		if ua := req.Header.Get("User-Agent"); ua == "" {
+			req.Header.Set("User-Agent", c.userAgentHeader())
+		} else {
+			req.Header.Set("User-Agent", c.userAgentHeader()+" "+ua)
+		}
+		if key != "" {
+			req.Header.Set(IdempotencyHeader, key)
+		}
+		r := newRequest(req, pathPart, data)
+		r.AccountSid = c.AccountSid
+		r.IdempotencyKey = key
+		if c.Limiter != nil {
+			if err := c.Limiter.Wait(ctx, r); err != nil {
+				return err
+			}
+		}
+		resp, err := c.do(r, v)
+		if err == nil {
+			return nil
+		}
+		p := c.RetryPolicy
+		if p == nil || attempt >= p.MaxAttempts || !retryable(ctx, method, resp, atomic.LoadInt32(&sent) == 1) {
+			return err
+		}
+		delay := p.delay(attempt, resp)
+		if p.OnRetry != nil {
+			ev := RetryEvent{
+				Method:         method,
+				Path:           pathPart,
+				Attempt:        attempt,
+				Err:            err,
+				Delay:          delay,
+				IdempotencyKey: key,
+			}
+			if resp != nil {
+				ev.StatusCode = resp.StatusCode
+			}
+			p.OnRetry(ev)
+		}
+		if sleep(ctx, delay) != nil {
+			return err
+		}
+	}
+}
+
+// do sends r through the Client's middleware and decodes a successful
+// response into v. Unlike restclient.Client.Do, do returns the response (with
+// the body already closed) so the caller can inspect the status code and
+// headers.
+func (c *Client) do(r *Request, v interface{}) (*http.Response, error) {
+	resp, err := c.send(r, c.roundTrip)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
 	if err != nil {
-		return err
+		return resp, err
+	}
+	resBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+	if v == nil || resp.StatusCode == http.StatusNoContent {
+		return resp, nil
 	}
-	req = withContext(req, ctx)
-	if ua := req.Header.Get("User-Agent"); ua == "" {
-		req.Header.Set("User-Agent", userAgent)
-	} else {
-		req.Header.Set("User-Agent", userAgent+" "+ua)
+	return resp, json.Unmarshal(resBody, v)
+}
+
+// productClient returns the product client for p, or nil if there isn't one.
+func (c *Client) productClient(p Product) *Client {
+	switch p {
+	case ProductMonitor:
+		return c.Monitor
+	case ProductPricing:
+		return c.Pricing
+	case ProductFax:
+		return c.Fax
+	case ProductWireless:
+		return c.Wireless
+	case ProductNotify:
+		return c.Notify
+	case ProductLookup:
+		return c.Lookup
+	case ProductVerify:
+		return c.Verify
+	case ProductVideo:
+		return c.Video
+	case ProductTaskRouter:
+		return c.TaskRouter
+	case ProductInsights:
+		return c.Insights
+	case ProductSuperSim:
+		return c.SuperSim
+	case ProductMessaging:
+		return c.MessagingServices
+	default:
+		return nil
+	}
+}
+
+// subClients returns the product clients created by NewClient, skipping any
+// that are nil.
+func (c *Client) subClients() []*Client {
+	all := []*Client{
+		c.Monitor, c.Pricing, c.Fax, c.Wireless, c.Notify, c.Lookup,
+		c.Verify, c.Video, c.TaskRouter, c.Insights, c.SuperSim, c.MessagingServices,
+	}
+	subs := all[:0]
+	for _, sub := range all {
+		if sub != nil {
+			subs = append(subs, sub)
+		}
 	}
-	return c.Do(req, &v)
+	return subs
 }
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -108,3 +110,131 @@ func TestOnBehalfOf(t *testing.T) {
 		t.Errorf("expected called to be true, got false")
 	}
 }
+
+func TestForAccount(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if pass != "456bef" {
+			w.WriteHeader(401)
+			return
+		}
+		mu.Lock()
+		seen[user+" "+r.URL.Path] = true
+		mu.Unlock()
+		w.WriteHeader(200)
+		w.Write([]byte("{}"))
+	}))
+	defer s.Close()
+	c := NewClient("AC123", "456bef", nil)
+	c.Base = s.URL
+	c.Wireless.Base = s.URL
+	var calls int32
+	c.Use(func(next Handler) Handler {
+		return func(r *Request) (*http.Response, error) {
+			atomic.AddInt32(&calls, 1)
+			return next(r)
+		}
+	})
+
+	var wg sync.WaitGroup
+	for _, sid := range []string{"AC345", "AC678"} {
+		wg.Add(1)
+		go func(sid string) {
+			defer wg.Done()
+			sub := c.ForAccount(sid)
+			if _, err := sub.Calls.Get(context.Background(), "CA123"); err != nil {
+				t.Error(err)
+			}
+			if _, err := sub.Wireless.Sims.Get(context.Background(), "DE123"); err != nil {
+				t.Error(err)
+			}
+		}(sid)
+	}
+	wg.Wait()
+	if _, err := c.Calls.Get(context.Background(), "CA123"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"AC123 /2010-04-01/Accounts/AC345/Calls/CA123.json",
+		"AC123 /2010-04-01/Accounts/AC678/Calls/CA123.json",
+		"AC123 /2010-04-01/Accounts/AC123/Calls/CA123.json",
+		"AC345 /v1/Sims/DE123",
+		"AC678 /v1/Sims/DE123",
+	} {
+		if !seen[want] {
+			t.Errorf("expected request %q, got %v", want, seen)
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 5 {
+		t.Errorf("expected middleware to see 5 requests, got %d", n)
+	}
+	if c.AccountSid != "AC123" || c.Client.ID != "AC123" || c.Wireless.Client.ID != "AC123" {
+		t.Errorf("ForAccount modified the parent client")
+	}
+}
+
+func TestForAccountCredentials(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		mu.Lock()
+		seen[user+" "+r.URL.Path] = true
+		mu.Unlock()
+		w.Write([]byte("{}"))
+	}))
+	defer s.Close()
+
+	c := NewClient("AC123", "456bef", nil)
+	c.Base = s.URL
+	c.Wireless.Base = s.URL
+	c.SetCredentialProvider(NewRotatingCredentials(Credentials{Username: "AC123", Password: "789abc"}))
+	sub := c.ForAccount("AC345")
+	if _, err := sub.Wireless.Sims.Get(context.Background(), "DE123"); err != nil {
+		t.Fatal(err)
+	}
+	if !seen["AC345 /v1/Sims/DE123"] {
+		t.Errorf("expected product request to authenticate as the subaccount, got %v", seen)
+	}
+
+	c.UseAPIKey("SK123", "secret")
+	sub = c.ForAccount("AC345")
+	if _, err := sub.Calls.Get(context.Background(), "CA123"); err != nil {
+		t.Fatal(err)
+	}
+	if !seen["SK123 /2010-04-01/Accounts/AC345/Calls/CA123.json"] {
+		t.Errorf("expected API request with the API key, got %v", seen)
+	}
+	_, err := sub.Wireless.Sims.Get(context.Background(), "DE456")
+	if err == nil || !strings.Contains(err.Error(), "AC345") {
+		t.Errorf("expected an error making a product request with an API key, got %v", err)
+	}
+	if seen["SK123 /v1/Sims/DE456"] {
+		t.Errorf("product request with an API key should not have been made")
+	}
+}
+
+func TestForAccountCache(t *testing.T) {
+	t.Parallel()
+	c := NewClient("AC123", "456bef", nil)
+	sub := c.ForAccount("AC345")
+	if c.ForAccount("AC345") != sub {
+		t.Errorf("expected ForAccount to return the same Client for the same subaccount")
+	}
+	if c.ForAccount("AC678") == sub {
+		t.Errorf("expected ForAccount to return a different Client for a different subaccount")
+	}
+	c.SetRetryPolicy(DefaultRetryPolicy)
+	if sub.RetryPolicy != nil {
+		t.Errorf("SetRetryPolicy changed a Client returned by ForAccount")
+	}
+	next := c.ForAccount("AC345")
+	if next == sub || next.RetryPolicy != DefaultRetryPolicy || next.Wireless.RetryPolicy != DefaultRetryPolicy {
+		t.Errorf("expected ForAccount to return a new Client after SetRetryPolicy")
+	}
+}
@@ -0,0 +1,46 @@
+package twilio
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// IdempotencyHeader is the header WithIdempotencyKey sets. Twilio may not use
+// it to recognize repeated requests.
+const IdempotencyHeader = "I-Twilio-Idempotency-Token"
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx that sends key as the idempotency
+// token of POST requests made with it, e.g. by Messages.Create, Calls.Create or
+// CreateResource. If key is empty, a new key is generated with
+// NewIdempotencyKey. Use IdempotencyKey to get the key for logging.
+//
+// Every attempt at a request made with the same Context sends the same key,
+// so you can match up repeated attempts in your logs and webhooks. Twilio may
+// ignore the header, so the key does not make a POST request safe to retry; a
+// RetryPolicy still only retries a POST if it was never sent. Use a new key for
+// each logical operation, e.g. each message you want to send.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	if key == "" {
+		key = NewIdempotencyKey()
+	}
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// IdempotencyKey returns the key set on ctx with WithIdempotencyKey, or the
+// empty string if there isn't one.
+func IdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKey{}).(string)
+	return key
+}
+
+// NewIdempotencyKey returns a random 32 character key.
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
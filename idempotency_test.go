@@ -0,0 +1,97 @@
+package twilio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+func newIdempotencyServer(failures int) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var keys []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get(IdempotencyHeader))
+		n := len(keys)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if n <= failures {
+			w.WriteHeader(500)
+			w.Write([]byte(`{"code": 20500, "message": "Internal Server Error", "status": 500}`))
+			return
+		}
+		w.WriteHeader(201)
+		w.Write(sendMessageResponse)
+	}))
+	return s, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), keys...)
+	}
+}
+
+func TestIdempotencyKeyNotRetried(t *testing.T) {
+	t.Parallel()
+	s, keys := newIdempotencyServer(1)
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	var events []RetryEvent
+	client.SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 3,
+		OnRetry:     func(ev RetryEvent) { events = append(events, ev) },
+	})
+	ctx := WithIdempotencyKey(context.Background(), "")
+	key := IdempotencyKey(ctx)
+	if len(key) != 32 {
+		t.Fatalf("expected a generated 32 character key, got %q", key)
+	}
+	// Twilio may ignore the key, so a POST that was sent is not retried.
+	_, err := client.Messages.Create(ctx, url.Values{"To": []string{to}, "From": []string{from}, "Body": []string{"hi"}})
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	sent := keys()
+	if len(sent) != 1 || sent[0] != key {
+		t.Fatalf("expected 1 attempt with key %q, got %q", key, sent)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected no retries, got %#v", events)
+	}
+	// The next attempt succeeds.
+	if _, err := client.Messages.Create(ctx, url.Values{"To": []string{to}}); err != nil {
+		t.Fatal(err)
+	}
+	if sent := keys(); len(sent) != 2 || sent[1] != key {
+		t.Errorf("expected the same key on the second request, got %q", sent)
+	}
+}
+
+func TestIdempotencyKeyUnset(t *testing.T) {
+	t.Parallel()
+	s, keys := newIdempotencyServer(0)
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	if _, err := client.Messages.SendMessage(from, to, "hi", nil); err != nil {
+		t.Fatal(err)
+	}
+	// GET requests don't send the key.
+	ctx := WithIdempotencyKey(context.Background(), "my-key")
+	if _, err := client.Messages.Get(ctx, "SM123"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Messages.Create(ctx, url.Values{"To": []string{to}}); err != nil {
+		t.Fatal(err)
+	}
+	sent := keys()
+	if len(sent) != 3 || sent[0] != "" || sent[1] != "" || sent[2] != "my-key" {
+		t.Errorf("bad idempotency headers: %q", sent)
+	}
+	if k := IdempotencyKey(context.Background()); k != "" {
+		t.Errorf("expected no key, got %q", k)
+	}
+}
@@ -0,0 +1,326 @@
+//go:build go1.23
+// +build go1.23
+
+package twilio
+
+import (
+	"context"
+	"iter"
+	"net/url"
+)
+
+// A Pager returns consecutive pages of resources. Every XPageIterator in this
+// package is a Pager.
+type Pager[P any] interface {
+	// Next returns the next page of resources. If there are no more
+	// resources, NoMoreResults is returned.
+	Next(context.Context) (P, error)
+}
+
+// Items returns an iterator over the individual resources in each page
+// returned by p. items extracts the resources from a page.
+//
+// The iterator yields a non-nil error at most once, as the last value. It
+// stops without an error after the last page, and yields ctx.Err() if ctx is
+// canceled. A new page is only requested once every item in the previous page
+// has been consumed. Because p is stateful, the returned iterator can only be
+// ranged over once.
+func Items[P any, T any](ctx context.Context, p Pager[P], items func(P) []T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			page, err := p.Next(ctx)
+			if err == NoMoreResults {
+				return
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items(page) {
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Limit returns an iterator that yields at most n items from seq. An error
+// from seq is passed through and ends the iteration; it does not count
+// against n. Once n items have been yielded, no further pages are requested.
+//
+//	for msg, err := range twilio.Limit(client.Messages.All(ctx, nil), 100) {
+//		...
+//	}
+func Limit[T any](seq iter.Seq2[T, error], n int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if n <= 0 {
+			return
+		}
+		count := 0
+		for item, err := range seq {
+			if !yield(item, err) || err != nil {
+				return
+			}
+			count++
+			if count >= n {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over every Account matching the filters in
+// data, fetching additional pages as needed.
+func (c *AccountService) All(ctx context.Context, data url.Values) iter.Seq2[*Account, error] {
+	return Items(ctx, c.GetPageIterator(data), func(p *AccountPage) []*Account { return p.Accounts })
+}
+
+// All returns an iterator over every Alert matching the filters in
+// data, fetching additional pages as needed.
+func (a *AlertService) All(ctx context.Context, data url.Values) iter.Seq2[*Alert, error] {
+	return Items(ctx, a.GetPageIterator(data), func(p *AlertPage) []*Alert { return p.Alerts })
+}
+
+// All returns an iterator over every Application matching the filters in
+// data, fetching additional pages as needed.
+func (c *ApplicationService) All(ctx context.Context, data url.Values) iter.Seq2[*Application, error] {
+	return Items(ctx, c.GetPageIterator(data), func(p *ApplicationPage) []*Application { return p.Applications })
+}
+
+// All returns an iterator over every Call matching the filters in
+// data, fetching additional pages as needed.
+func (c *CallService) All(ctx context.Context, data url.Values) iter.Seq2[*Call, error] {
+	return Items(ctx, c.GetPageIterator(data), func(p *CallPage) []*Call { return p.Calls })
+}
+
+// All returns an iterator over every Conference matching the filters in
+// data, fetching additional pages as needed.
+func (c *ConferenceService) All(ctx context.Context, data url.Values) iter.Seq2[*Conference, error] {
+	return Items(ctx, c.GetPageIterator(data), func(p *ConferencePage) []*Conference { return p.Conferences })
+}
+
+// All returns an iterator over every Fax matching the filters in
+// data, fetching additional pages as needed.
+func (f *FaxService) All(ctx context.Context, data url.Values) iter.Seq2[*Fax, error] {
+	return Items(ctx, f.GetPageIterator(data), func(p *FaxPage) []*Fax { return p.Faxes })
+}
+
+// All returns an iterator over every IncomingPhoneNumber matching the filters in
+// data, fetching additional pages as needed.
+func (c *IncomingNumberService) All(ctx context.Context, data url.Values) iter.Seq2[*IncomingPhoneNumber, error] {
+	return Items(ctx, c.GetPageIterator(data), func(p *IncomingPhoneNumberPage) []*IncomingPhoneNumber { return p.IncomingPhoneNumbers })
+}
+
+// All returns an iterator over every Key matching the filters in
+// data, fetching additional pages as needed.
+func (c *KeyService) All(ctx context.Context, data url.Values) iter.Seq2[*Key, error] {
+	return Items(ctx, c.GetPageIterator(data), func(p *KeyPage) []*Key { return p.Keys })
+}
+
+// All returns an iterator over every Message matching the filters in
+// data, fetching additional pages as needed.
+func (m *MessageService) All(ctx context.Context, data url.Values) iter.Seq2[*Message, error] {
+	return Items(ctx, m.GetPageIterator(data), func(p *MessagePage) []*Message { return p.Messages })
+}
+
+// All returns an iterator over every NotifyCredential matching the filters in
+// data, fetching additional pages as needed.
+func (n *NotifyCredentialsService) All(ctx context.Context, data url.Values) iter.Seq2[*NotifyCredential, error] {
+	return Items(ctx, n.GetPageIterator(data), func(p *NotifyCredentialPage) []*NotifyCredential { return p.Credentials })
+}
+
+// All returns an iterator over every OutgoingCallerID matching the filters in
+// data, fetching additional pages as needed.
+func (o *OutgoingCallerIDService) All(ctx context.Context, data url.Values) iter.Seq2[*OutgoingCallerID, error] {
+	return Items(ctx, o.GetPageIterator(data), func(p *OutgoingCallerIDPage) []*OutgoingCallerID { return p.OutgoingCallerIDs })
+}
+
+// All returns an iterator over every PriceCountry matching the filters in
+// data, fetching additional pages as needed.
+func (cmps *CountryMessagingPriceService) All(ctx context.Context, data url.Values) iter.Seq2[*PriceCountry, error] {
+	return Items(ctx, cmps.GetPageIterator(data), func(p *CountriesPricePage) []*PriceCountry { return p.Countries })
+}
+
+// All returns an iterator over every PriceCountry matching the filters in
+// data, fetching additional pages as needed.
+func (cpnps *CountryPhoneNumberPriceService) All(ctx context.Context, data url.Values) iter.Seq2[*PriceCountry, error] {
+	return Items(ctx, cpnps.GetPageIterator(data), func(p *CountriesPricePage) []*PriceCountry { return p.Countries })
+}
+
+// All returns an iterator over every PriceCountry matching the filters in
+// data, fetching additional pages as needed.
+func (cvps *CountryVoicePriceService) All(ctx context.Context, data url.Values) iter.Seq2[*PriceCountry, error] {
+	return Items(ctx, cvps.GetPageIterator(data), func(p *CountriesPricePage) []*PriceCountry { return p.Countries })
+}
+
+// All returns an iterator over every Queue matching the filters in
+// data, fetching additional pages as needed.
+func (c *QueueService) All(ctx context.Context, data url.Values) iter.Seq2[*Queue, error] {
+	return Items(ctx, c.GetPageIterator(data), func(p *QueuePage) []*Queue { return p.Queues })
+}
+
+// All returns an iterator over every Recording matching the filters in
+// data, fetching additional pages as needed.
+func (r *RecordingService) All(ctx context.Context, data url.Values) iter.Seq2[*Recording, error] {
+	return Items(ctx, r.GetPageIterator(data), func(p *RecordingPage) []*Recording { return p.Recordings })
+}
+
+// All returns an iterator over every Room matching the filters in
+// data, fetching additional pages as needed.
+func (r *RoomService) All(ctx context.Context, data url.Values) iter.Seq2[*Room, error] {
+	return Items(ctx, r.GetPageIterator(data), func(p *RoomPage) []*Room { return p.Rooms })
+}
+
+// All returns an iterator over every SuperSim matching the filters in
+// data, fetching additional pages as needed.
+func (s *SuperSimService) All(ctx context.Context, data url.Values) iter.Seq2[*SuperSim, error] {
+	return Items(ctx, s.GetPageIterator(data), func(p *SuperSimPage) []*SuperSim { return p.SuperSims })
+}
+
+// All returns an iterator over every Network matching the filters in
+// data, fetching additional pages as needed.
+func (s *NetworkService) All(ctx context.Context, data url.Values) iter.Seq2[*Network, error] {
+	return Items(ctx, s.GetNetworkPageIterator(data), func(p *NetworkPage) []*Network { return p.Networks })
+}
+
+// All returns an iterator over every Activity matching the filters in
+// data, fetching additional pages as needed.
+func (c *ActivityService) All(ctx context.Context, data url.Values) iter.Seq2[*Activity, error] {
+	return Items(ctx, c.GetPageIterator(data), func(p *ActivityPage) []*Activity { return p.Activities })
+}
+
+// All returns an iterator over every TaskQueue matching the filters in
+// data, fetching additional pages as needed.
+func (c *TaskQueueService) All(ctx context.Context, data url.Values) iter.Seq2[*TaskQueue, error] {
+	return Items(ctx, c.GetPageIterator(data), func(p *TaskQueuePage) []*TaskQueue { return p.TaskQueues })
+}
+
+// All returns an iterator over every Worker matching the filters in
+// data, fetching additional pages as needed.
+func (c *WorkerService) All(ctx context.Context, data url.Values) iter.Seq2[*Worker, error] {
+	return Items(ctx, c.GetPageIterator(data), func(p *WorkerPage) []*Worker { return p.Workers })
+}
+
+// All returns an iterator over every Workflow matching the filters in
+// data, fetching additional pages as needed.
+func (c *WorkflowService) All(ctx context.Context, data url.Values) iter.Seq2[*Workflow, error] {
+	return Items(ctx, c.GetPageIterator(data), func(p *WorkflowPage) []*Workflow { return p.Workflows })
+}
+
+// All returns an iterator over every Transcription matching the filters in
+// data, fetching additional pages as needed.
+func (c *TranscriptionService) All(ctx context.Context, data url.Values) iter.Seq2[*Transcription, error] {
+	return Items(ctx, c.GetPageIterator(data), func(p *TranscriptionPage) []*Transcription { return p.Transcriptions })
+}
+
+// All returns an iterator over every VideoRecording matching the filters in
+// data, fetching additional pages as needed.
+func (vr *VideoRecordingService) All(ctx context.Context, data url.Values) iter.Seq2[*VideoRecording, error] {
+	return Items(ctx, vr.GetPageIterator(data), func(p *VideoRecordingPage) []*VideoRecording { return p.Recordings })
+}
+
+// All returns an iterator over every CallEvent matching the filters in
+// data, fetching additional pages as needed.
+func (s *CallEventsService) All(ctx context.Context, data url.Values) iter.Seq2[CallEvent, error] {
+	return Items(ctx, s.GetPageIterator(data), func(p *CallEventsPage) []CallEvent { return p.Events })
+}
+
+// All returns an iterator over every CallMetric matching the filters in
+// data, fetching additional pages as needed.
+func (s *CallMetricsService) All(ctx context.Context, data url.Values) iter.Seq2[CallMetric, error] {
+	return Items(ctx, s.GetPageIterator(data), func(p *CallMetricsPage) []CallMetric { return p.Metrics })
+}
+
+// All returns an iterator over every Sim matching the filters in
+// data, fetching additional pages as needed.
+func (f *SimService) All(ctx context.Context, data url.Values) iter.Seq2[*Sim, error] {
+	return Items(ctx, f.GetPageIterator(data), func(p *SimPage) []*Sim { return p.Sims })
+}
+
+// All returns an iterator over every Command matching the filters in
+// data, fetching additional pages as needed.
+func (f *CommandService) All(ctx context.Context, data url.Values) iter.Seq2[*Command, error] {
+	return Items(ctx, f.GetPageIterator(data), func(p *CommandPage) []*Command { return p.Commands })
+}
+
+// All returns an iterator over every Participant in the Conference matching
+// the filters in data, fetching additional pages as needed.
+func (p *ParticipantService) All(ctx context.Context, conferenceSid string, data url.Values) iter.Seq2[*Participant, error] {
+	return Items(ctx, p.GetPageIterator(conferenceSid, data), func(pp *ParticipantPage) []*Participant { return pp.Participants })
+}
+
+// All returns an iterator over every call waiting in the Queue, fetching
+// additional pages as needed.
+func (c *QueueMemberService) All(ctx context.Context, queueSid string, data url.Values) iter.Seq2[*QueueMember, error] {
+	return Items(ctx, c.GetPageIterator(queueSid, data), func(p *QueueMemberPage) []*QueueMember { return p.Members })
+}
+
+// All returns an iterator over every notification for the Call, fetching
+// additional pages as needed.
+func (c *CallNotificationService) All(ctx context.Context, callSid string, data url.Values) iter.Seq2[*CallNotification, error] {
+	return Items(ctx, c.GetPageIterator(callSid, data), func(p *CallNotificationPage) []*CallNotification { return p.Notifications })
+}
+
+// All returns an iterator over every request event for the Call, fetching
+// additional pages as needed.
+func (c *CallRequestEventService) All(ctx context.Context, callSid string, data url.Values) iter.Seq2[*CallRequestEvent, error] {
+	return Items(ctx, c.GetPageIterator(callSid, data), func(p *CallRequestEventPage) []*CallRequestEvent { return p.Events })
+}
+
+// All returns an iterator over every Usage Record matching the filters in
+// data, fetching additional pages as needed.
+func (u *UsageRecordService) All(ctx context.Context, data url.Values) iter.Seq2[*AccountUsageRecord, error] {
+	return Items(ctx, u.GetPageIterator(data), func(p *AccountUsageRecordPage) []*AccountUsageRecord { return p.UsageRecords })
+}
+
+// AllInterval returns an iterator over every Usage Record grouped by interval,
+// fetching additional pages as needed.
+func (u *UsageRecordService) AllInterval(ctx context.Context, interval UsageInterval, data url.Values) iter.Seq2[*AccountUsageRecord, error] {
+	return Items(ctx, u.GetIntervalPageIterator(interval, data), func(p *AccountUsageRecordPage) []*AccountUsageRecord { return p.UsageRecords })
+}
+
+// All returns an iterator over every UsageTrigger matching the filters in
+// data, fetching additional pages as needed.
+func (u *UsageTriggerService) All(ctx context.Context, data url.Values) iter.Seq2[*UsageTrigger, error] {
+	return Items(ctx, u.GetPageIterator(data), func(p *UsageTriggerPage) []*UsageTrigger { return p.UsageTriggers })
+}
+
+// Scheduled returns an iterator over every Message that is scheduled to be
+// sent, optionally further filtered by data. See GetScheduledPageIterator.
+func (m *MessageService) Scheduled(ctx context.Context, data url.Values) iter.Seq2[*Message, error] {
+	return Items(ctx, m.GetScheduledPageIterator(data), func(p *MessagePage) []*Message { return p.Messages })
+}
+
+// All returns an iterator over every Messaging Service matching the filters
+// in data, fetching additional pages as needed.
+func (s *MessagingServiceService) All(ctx context.Context, data url.Values) iter.Seq2[*MessagingService, error] {
+	return Items(ctx, s.GetPageIterator(data), func(p *MessagingServicePage) []*MessagingService { return p.Services })
+}
+
+// All returns an iterator over every phone number in the sender pool,
+// fetching additional pages as needed.
+func (s *ServicePhoneNumberService) All(ctx context.Context, data url.Values) iter.Seq2[*ServicePhoneNumber, error] {
+	return Items(ctx, s.GetPageIterator(data), func(p *ServicePhoneNumberPage) []*ServicePhoneNumber { return p.PhoneNumbers })
+}
+
+// All returns an iterator over every short code in the sender pool, fetching
+// additional pages as needed.
+func (s *ServiceShortCodeService) All(ctx context.Context, data url.Values) iter.Seq2[*ServiceShortCode, error] {
+	return Items(ctx, s.GetPageIterator(data), func(p *ServiceShortCodePage) []*ServiceShortCode { return p.ShortCodes })
+}
+
+// All returns an iterator over every alphanumeric sender ID of the Messaging
+// Service, fetching additional pages as needed.
+func (s *AlphaSenderService) All(ctx context.Context, data url.Values) iter.Seq2[*AlphaSender, error] {
+	return Items(ctx, s.GetPageIterator(data), func(p *AlphaSenderPage) []*AlphaSender { return p.AlphaSenders })
+}
@@ -0,0 +1,97 @@
+//go:build go1.23
+// +build go1.23
+
+package twilio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+var messagesPage1 = []byte(`{"messages": [{"sid": "SM1"}, {"sid": "SM2"}], "next_page_uri": "/2010-04-01/Accounts/AC123/Messages.json?PageToken=PASM2&Page=1"}`)
+var messagesPage2 = []byte(`{"messages": [{"sid": "SM3"}], "next_page_uri": null}`)
+
+func newPagingServer() (*httptest.Server, *int32) {
+	var count int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if r.URL.Query().Get("PageToken") == "" {
+			w.Write(messagesPage1)
+		} else {
+			w.Write(messagesPage2)
+		}
+	}))
+	return s, &count
+}
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+	s, count := newPagingServer()
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	var sids []string
+	for msg, err := range client.Messages.All(context.Background(), nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		sids = append(sids, msg.Sid)
+	}
+	if len(sids) != 3 || sids[0] != "SM1" || sids[2] != "SM3" {
+		t.Errorf("bad sids: %v", sids)
+	}
+	if n := atomic.LoadInt32(count); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
+
+func TestAllLimit(t *testing.T) {
+	t.Parallel()
+	s, count := newPagingServer()
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	var sids []string
+	for msg, err := range Limit(client.Messages.All(context.Background(), nil), 2) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		sids = append(sids, msg.Sid)
+	}
+	if len(sids) != 2 {
+		t.Errorf("expected 2 messages, got %v", sids)
+	}
+	if n := atomic.LoadInt32(count); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
+
+func TestAllCanceled(t *testing.T) {
+	t.Parallel()
+	s, _ := newPagingServer()
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var gotErr error
+	n := 0
+	for _, err := range client.Messages.All(ctx, nil) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		n++
+		cancel()
+	}
+	if n != 1 {
+		t.Errorf("expected 1 message before cancellation, got %d", n)
+	}
+	if gotErr != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", gotErr)
+	}
+}
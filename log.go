@@ -0,0 +1,151 @@
+package twilio
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// redactedParams are request parameters whose values are never logged, along
+// with those matched by secretWords and presigned URL parameters like
+// X-Amz-Signature; see isRedacted.
+var redactedParams = map[string]bool{
+	"Body":        true,
+	"Twiml":       true,
+	"AuthToken":   true,
+	"Password":    true,
+	"Secret":      true,
+	"ApiSecret":   true,
+	"ApiKey":      true,
+	"Token":       true,
+	"Credential":  true,
+	"Certificate": true,
+	"PrivateKey":  true,
+	"Signature":   true,
+}
+
+// secretWords are lowercase substrings of the names of parameters that hold
+// secrets, like the PrivateKey of a Notify credential.
+var secretWords = []string{"key", "secret", "token", "password", "certificate"}
+
+// phoneNumberRe matches E.164 phone numbers, including those after a channel
+// prefix like "whatsapp:".
+var phoneNumberRe = regexp.MustCompile(`\+[1-9][0-9]{6,14}`)
+
+// SetLogger configures the Client, and every product Client created by
+// NewClient (Monitor, Pricing, Wireless, etc), to log each API request to l.
+// Pass nil to disable logging, which is the default.
+//
+// SetLogger is not thread safe; call it before making any requests.
+func (c *Client) SetLogger(l *slog.Logger) {
+	c.Logger = l
+	c.forgetAccounts()
+	for _, sub := range c.subClients() {
+		sub.SetLogger(l)
+	}
+}
+
+// logRequests returns a Handler that logs each request sent by h to l.
+//
+// Each attempt is logged with its method, path, status, duration, Twilio
+// request id and error code, at level Info, or Warn if it failed. At level
+// Debug, the request parameters are logged too. Headers are never logged, the
+// values of parameters like Body, Twiml, Password and PrivateKey are redacted,
+// and phone numbers are masked with PhoneNumber.Masked.
+func logRequests(l *slog.Logger, h Handler) Handler {
+	return func(r *Request) (*http.Response, error) {
+		ctx := r.HTTPRequest.Context()
+		start := time.Now()
+		resp, err := h(r)
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", maskPath(r.Path)),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if resp != nil {
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			if id := resp.Header.Get("Twilio-Request-Id"); id != "" {
+				attrs = append(attrs, slog.String("request_id", id))
+			}
+		}
+		if r.IdempotencyKey != "" {
+			attrs = append(attrs, slog.String("idempotency_key", r.IdempotencyKey))
+		}
+		level := slog.LevelInfo
+		if err != nil {
+			level = slog.LevelWarn
+			if terr, ok := AsError(err); ok && terr.Code != 0 {
+				attrs = append(attrs, slog.Int("error_code", int(terr.Code)))
+			}
+			attrs = append(attrs, slog.String("error", maskPhoneNumbers(err.Error())))
+		}
+		if len(r.Data) > 0 && l.Enabled(ctx, slog.LevelDebug) {
+			attrs = append(attrs, slog.Any("params", redactParams(r.Data)))
+		}
+		l.LogAttrs(ctx, level, "twilio request", attrs...)
+		return resp, err
+	}
+}
+
+// redactParams returns a copy of data that is safe to log.
+func redactParams(data url.Values) slog.Value {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		val := strings.Join(data[k], ",")
+		if isRedacted(k) {
+			val = "[redacted]"
+		} else {
+			val = maskPhoneNumbers(val)
+		}
+		attrs = append(attrs, slog.String(k, val))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// isRedacted reports whether the value of the parameter k should not be
+// logged.
+func isRedacted(k string) bool {
+	if redactedParams[k] {
+		return true
+	}
+	lower := strings.ToLower(k)
+	if strings.HasPrefix(lower, "x-amz-") {
+		return true
+	}
+	for _, word := range secretWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskPhoneNumbers masks every phone number in s.
+func maskPhoneNumbers(s string) string {
+	return phoneNumberRe.ReplaceAllStringFunc(s, func(pn string) string {
+		return PhoneNumber(pn).Masked()
+	})
+}
+
+// maskPath masks phone numbers in a resource path, including national numbers
+// passed to the Lookup API, e.g. "/v1/PhoneNumbers/4105551234".
+func maskPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if len(part) >= 7 && strings.Trim(part, "0123456789") == "" {
+			parts[i] = maskDigits(part, 2)
+		} else {
+			parts[i] = maskPhoneNumbers(part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
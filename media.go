@@ -1,7 +1,6 @@
 package twilio
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -12,10 +11,10 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
-	"os"
 	"strings"
+
+	"github.com/kevinburke/rest/restclient"
 )
 
 // A MediaService lets you retrieve a message's associated Media.
@@ -55,6 +54,41 @@ func (m *MediaService) Get(ctx context.Context, messageSid string, sid string) (
 	return me, err
 }
 
+// Delete the Media with the given sid from the Message with the given
+// messageSid. If the Media has already been deleted, or does not exist,
+// Delete returns nil. If another error or a timeout occurs, the error is
+// returned.
+func (m *MediaService) Delete(ctx context.Context, messageSid string, sid string) error {
+	return m.client.DeleteResource(ctx, mediaPathPart(messageSid), sid)
+}
+
+// mediaClient returns MediaClient, with the Transport of the Client's
+// http.Client if it has a custom one, so Media requests go through the same
+// RoundTripper (a proxy, or a twiliotest.Recorder) as API requests.
+func (m *MediaService) mediaClient() *http.Client {
+	mc := MediaClient
+	if hc := m.client.Client.Client; hc != nil && hc.Transport != nil && hc.Transport != restclient.DefaultTransport {
+		mc.Transport = hc.Transport
+	}
+	return &mc
+}
+
+// send sends a Media request through the Client's middleware. Redirects are
+// not followed. The Request has no Data, since the query string of a media
+// URL may be a presigned signature.
+func (m *MediaService) send(req *http.Request) (*http.Response, error) {
+	client := m.mediaClient()
+	r := &Request{
+		Method:      req.Method,
+		Path:        req.URL.Path,
+		AccountSid:  m.client.AccountSid,
+		HTTPRequest: req,
+	}
+	return m.client.send(r, func(r *Request) (*http.Response, error) {
+		return client.Do(r.HTTPRequest)
+	})
+}
+
 // GetURL returns a URL that can be retrieved to download the given image.
 func (m *MediaService) GetURL(ctx context.Context, messageSid string, sid string) (*url.URL, error) {
 	uriEnd := strings.Join([]string{mediaPathPart(messageSid), sid}, "/")
@@ -71,33 +105,16 @@ func (m *MediaService) GetURL(ctx context.Context, messageSid string, sid string
 			return nil, err
 		}
 		req = withContext(req, ctx)
-		req.SetBasicAuth(m.client.AccountSid, m.client.AuthToken)
-		req.Header.Set("User-Agent", userAgent)
-		if os.Getenv("DEBUG_HTTP_TRAFFIC") == "true" || os.Getenv("DEBUG_HTTP_REQUEST") == "true" {
-			b := new(bytes.Buffer)
-			bits, _ := httputil.DumpRequestOut(req, true)
-			if len(bits) > 0 && bits[len(bits)-1] != '\n' {
-				bits = append(bits, '\n')
-			}
-			b.Write(bits)
-			io.Copy(os.Stderr, b)
+		req.SetBasicAuth(m.client.Client.ID, m.client.Client.Token)
+		if err := m.client.setAuth(ctx, req); err != nil {
+			return nil, err
 		}
-		resp, err := MediaClient.Do(req)
+		req.Header.Set("User-Agent", m.client.userAgentHeader())
+		resp, err := m.send(req)
 		if err != nil {
 			return nil, err
 		}
-		if os.Getenv("DEBUG_HTTP_TRAFFIC") == "true" || os.Getenv("DEBUG_HTTP_RESPONSES") == "true" {
-			b := new(bytes.Buffer)
-			bits, _ := httputil.DumpResponse(resp, true)
-			if len(bits) > 0 && bits[len(bits)-1] != '\n' {
-				bits = append(bits, '\n')
-			}
-			b.Write(bits)
-			io.Copy(os.Stderr, b)
-		} else {
-			io.Copy(ioutil.Discard, resp.Body)
-		}
-
+		io.Copy(ioutil.Discard, resp.Body)
 		resp.Body.Close()
 		// This is brittle because we need to detect/rewrite the S3 URL.
 		// I don't want to hard code a S3 URL but we have to do some
@@ -151,8 +168,8 @@ func (m *MediaService) GetImage(ctx context.Context, messageSid string, sid stri
 		return nil, err
 	}
 	req = withContext(req, ctx)
-	req.Header.Set("User-Agent", userAgent)
-	resp, err := MediaClient.Do(req)
+	req.Header.Set("User-Agent", m.client.userAgentHeader())
+	resp, err := m.send(req)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,8 @@ package twilio
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -55,3 +57,21 @@ func TestGetImage(t *testing.T) {
 		t.Errorf("Invalid picture bounds: %v", bounds)
 	}
 }
+
+func TestDeleteMedia(t *testing.T) {
+	t.Parallel()
+	var method, path string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	if err := client.Media.Delete(context.Background(), "MM123", "ME123"); err != nil {
+		t.Fatal(err)
+	}
+	if method != "DELETE" || path != "/2010-04-01/Accounts/AC123/Messages/MM123/Media/ME123.json" {
+		t.Errorf("bad request: %s %s", method, path)
+	}
+}
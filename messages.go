@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	types "github.com/kevinburke/go-types"
 	"golang.org/x/sync/errgroup"
@@ -108,6 +110,121 @@ func (m *MessageService) SendMessage(from string, to string, body string, mediaU
 	return m.Create(context.Background(), v)
 }
 
+// MaxBodyLength is the maximum length, in characters, of a Message body.
+const MaxBodyLength = 1600
+
+// MaxMediaURLs is the maximum number of MediaURLs in a Message.
+const MaxMediaURLs = 10
+
+// MinScheduleDelay and MaxScheduleDelay bound how far in the future a Message
+// can be scheduled with MessageParams.SendAt.
+const (
+	MinScheduleDelay = 15 * time.Minute
+	MaxScheduleDelay = 35 * 24 * time.Hour
+)
+
+// MessageParams are the parameters for sending a Message. For more
+// information, see
+// https://www.twilio.com/docs/sms/api/message-resource#create-a-message-resource.
+type MessageParams struct {
+	// To is the recipient, an E.164 phone number like "+14105551234", or a
+	// channel address like "whatsapp:+14105551234".
+	To string
+	// From is the sender: a phone number, short code, alphanumeric sender ID
+	// or channel address. Either From or MessagingServiceSid is required.
+	From                string
+	MessagingServiceSid string
+	// Body is the text of the message, up to MaxBodyLength characters. Either
+	// Body or MediaURLs is required.
+	Body      string
+	MediaURLs []string
+	// StatusCallback is the URL Twilio sends status updates to.
+	StatusCallback  string
+	ApplicationSid  string
+	MaxPrice        string
+	ProvideFeedback bool
+	// ValidityPeriod is how many seconds the message can wait in the queue,
+	// between 1 and 14400. Zero uses Twilio's default.
+	ValidityPeriod int
+	// SendAt, if set, schedules the message to be sent at that time, between
+	// MinScheduleDelay and MaxScheduleDelay from now. Scheduled messages must
+	// be sent with a MessagingServiceSid, and have the status
+	// StatusScheduled until they're sent. Cancel them with CancelScheduled.
+	SendAt time.Time
+}
+
+// Validate returns a *ValidationError if p is missing a required parameter,
+// has an invalid phone number, has a Body that is too long, or is scheduled
+// outside Twilio's scheduling window.
+func (p *MessageParams) Validate() error {
+	if err := validateAddress("To", p.To); err != nil {
+		return err
+	}
+	if p.From == "" && p.MessagingServiceSid == "" {
+		return &ValidationError{Field: "From", Message: "either From or MessagingServiceSid must be set"}
+	}
+	if strings.HasPrefix(p.From, "+") || strings.HasPrefix(p.From, "whatsapp:") {
+		if err := validateAddress("From", p.From); err != nil {
+			return err
+		}
+	}
+	if p.Body == "" && len(p.MediaURLs) == 0 {
+		return &ValidationError{Field: "Body", Message: "either Body or MediaUrl must be set"}
+	}
+	if n := utf8.RuneCountInString(p.Body); n > MaxBodyLength {
+		return &ValidationError{Field: "Body", Message: fmt.Sprintf("body is %d characters, more than the maximum of %d", n, MaxBodyLength)}
+	}
+	if len(p.MediaURLs) > MaxMediaURLs {
+		return &ValidationError{Field: "MediaUrl", Message: fmt.Sprintf("%d media URLs, more than the maximum of %d", len(p.MediaURLs), MaxMediaURLs)}
+	}
+	if p.ValidityPeriod < 0 || p.ValidityPeriod > 14400 {
+		return &ValidationError{Field: "ValidityPeriod", Message: "must be between 1 and 14400 seconds"}
+	}
+	if !p.SendAt.IsZero() {
+		if p.MessagingServiceSid == "" {
+			return &ValidationError{Field: "MessagingServiceSid", Message: "scheduled messages must be sent with a Messaging Service"}
+		}
+		delay := time.Until(p.SendAt)
+		if delay < MinScheduleDelay || delay > MaxScheduleDelay {
+			return &ValidationError{Field: "SendAt", Message: fmt.Sprintf("must be between %v and %v from now, got %v", MinScheduleDelay, MaxScheduleDelay, delay.Round(time.Second))}
+		}
+	}
+	return nil
+}
+
+// Values returns the form values Twilio expects for p.
+func (p *MessageParams) Values() url.Values {
+	v := url.Values{}
+	setString(v, "To", p.To)
+	setString(v, "From", p.From)
+	setString(v, "MessagingServiceSid", p.MessagingServiceSid)
+	setString(v, "Body", p.Body)
+	for _, u := range p.MediaURLs {
+		v.Add("MediaUrl", u)
+	}
+	setString(v, "StatusCallback", p.StatusCallback)
+	setString(v, "ApplicationSid", p.ApplicationSid)
+	setString(v, "MaxPrice", p.MaxPrice)
+	if p.ProvideFeedback {
+		v.Set("ProvideFeedback", "true")
+	}
+	setInt(v, "ValidityPeriod", p.ValidityPeriod)
+	if !p.SendAt.IsZero() {
+		v.Set("ScheduleType", "fixed")
+		v.Set("SendAt", p.SendAt.UTC().Format(time.RFC3339))
+	}
+	return v
+}
+
+// CreateWithParams validates p and sends a Message. If p is invalid, it
+// returns a *ValidationError without making a request.
+func (m *MessageService) CreateWithParams(ctx context.Context, p *MessageParams) (*Message, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return m.Create(ctx, p.Values())
+}
+
 // MessagePageIterator lets you retrieve consecutive pages of resources.
 type MessagePageIterator interface {
 	// Next returns the next page of resources. If there are no more resources,
@@ -152,6 +269,62 @@ func (m *MessageService) GetPage(ctx context.Context, data url.Values) (*Message
 	return iter.Next(ctx)
 }
 
+// Update the Message with the given sid. For more information on valid
+// values, see
+// https://www.twilio.com/docs/sms/api/message-resource#update-a-message-resource.
+func (m *MessageService) Update(ctx context.Context, sid string, data url.Values) (*Message, error) {
+	msg := new(Message)
+	err := m.client.UpdateResource(ctx, messagesPathPart, sid, data, msg)
+	return msg, err
+}
+
+// CancelScheduled cancels the scheduled Message with the given sid, so it
+// won't be sent. It returns an error if the Message is not scheduled.
+func (m *MessageService) CancelScheduled(ctx context.Context, sid string) (*Message, error) {
+	data := url.Values{}
+	data.Set("Status", string(StatusCanceled))
+	return m.Update(ctx, sid, data)
+}
+
+// GetScheduledPageIterator returns an iterator over the Messages that are
+// scheduled to be sent, optionally further filtered by data. Twilio can't
+// filter Messages by status, so this pages through every Message matching
+// data, and may make several requests for each page it returns. Returned
+// MessagePages will have at least one result, but may have fewer than
+// PageSize.
+func (m *MessageService) GetScheduledPageIterator(data url.Values) MessagePageIterator {
+	return &messageStatusIterator{
+		p:      NewPageIterator(m.client, data, messagesPathPart),
+		status: StatusScheduled,
+	}
+}
+
+type messageStatusIterator struct {
+	p      *PageIterator
+	status Status
+}
+
+// Next returns the next page of Messages with the iterator's status.
+func (m *messageStatusIterator) Next(ctx context.Context) (*MessagePage, error) {
+	for {
+		page := new(MessagePage)
+		if err := m.p.Next(ctx, page); err != nil {
+			return nil, err
+		}
+		m.p.SetNextPageURI(page.NextPageURI)
+		msgs := page.Messages[:0]
+		for _, msg := range page.Messages {
+			if msg.Status == m.status {
+				msgs = append(msgs, msg)
+			}
+		}
+		page.Messages = msgs
+		if len(msgs) > 0 {
+			return page, nil
+		}
+	}
+}
+
 // Delete the Message with the given sid. If the Message has already been
 // deleted, or does not exist, Delete returns nil. If another error or a
 // timeout occurs, the error is returned.
@@ -159,6 +332,15 @@ func (m *MessageService) Delete(ctx context.Context, sid string) error {
 	return m.client.DeleteResource(ctx, messagesPathPart, sid)
 }
 
+// Redact removes the Body of the Message with the given sid, and keeps the
+// rest of the Message, like its status and price, e.g. for billing. Redact
+// does not remove the Message's Media; delete them with Media.Delete first.
+func (m *MessageService) Redact(ctx context.Context, sid string) (*Message, error) {
+	data := url.Values{}
+	data.Set("Body", "")
+	return m.Update(ctx, sid, data)
+}
+
 // GetMessagesInRange gets an Iterator containing calls in the range [start,
 // end), optionally further filtered by data. GetMessagesInRange panics if
 // start is not before end. Any date filters provided in data will be ignored.
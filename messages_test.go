@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"strings"
@@ -228,3 +230,94 @@ func TestWhatsappMessageParsing(t *testing.T) {
 		t.Errorf("bad Local: %v", m.To.Local())
 	}
 }
+
+func TestCancelScheduled(t *testing.T) {
+	t.Parallel()
+	var path, status string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		path, status = r.URL.Path, r.PostForm.Get("Status")
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(`{"sid": "SM123", "status": "canceled"}`))
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	msg, err := client.Messages.CancelScheduled(context.Background(), "SM123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "/2010-04-01/Accounts/AC123/Messages/SM123.json" {
+		t.Errorf("bad path: %q", path)
+	}
+	if status != "canceled" {
+		t.Errorf("expected Status=canceled, got %q", status)
+	}
+	if msg.Status != StatusCanceled {
+		t.Errorf("expected StatusCanceled, got %q", msg.Status)
+	}
+}
+
+func TestGetScheduledPageIterator(t *testing.T) {
+	t.Parallel()
+	pages := map[string]string{
+		"":  `{"messages": [{"sid": "SM1", "status": "delivered"}, {"sid": "SM2", "status": "scheduled"}], "next_page_uri": "/2010-04-01/Accounts/AC123/Messages.json?Page=1"}`,
+		"1": `{"messages": [{"sid": "SM3", "status": "sent"}], "next_page_uri": "/2010-04-01/Accounts/AC123/Messages.json?Page=2"}`,
+		"2": `{"messages": [{"sid": "SM4", "status": "scheduled"}, {"sid": "SM5", "status": "canceled"}], "next_page_uri": null}`,
+	}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(pages[r.URL.Query().Get("Page")]))
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	iter := client.Messages.GetScheduledPageIterator(nil)
+	var sids []string
+	for {
+		page, err := iter.Next(context.Background())
+		if err == NoMoreResults {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Messages) == 0 {
+			t.Error("expected every page to have at least one Message")
+		}
+		for _, msg := range page.Messages {
+			sids = append(sids, msg.Sid)
+		}
+	}
+	if strings.Join(sids, ",") != "SM2,SM4" {
+		t.Errorf("expected scheduled messages SM2,SM4, got %v", sids)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+	var method, path string
+	var form url.Values
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		method, path, form = r.Method, r.URL.Path, r.PostForm
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(`{"sid": "SM123", "body": "", "status": "delivered", "price": "-0.00750"}`))
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	msg, err := client.Messages.Redact(context.Background(), "SM123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != "POST" || path != "/2010-04-01/Accounts/AC123/Messages/SM123.json" {
+		t.Errorf("bad request: %s %s", method, path)
+	}
+	if body, ok := form["Body"]; !ok || len(body) != 1 || body[0] != "" {
+		t.Errorf("expected an empty Body parameter, got %v", form)
+	}
+	if msg.Body != "" || msg.Price != "-0.00750" {
+		t.Errorf("bad message: %#v", msg)
+	}
+}
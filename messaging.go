@@ -0,0 +1,377 @@
+package twilio
+
+import (
+	"context"
+	"net/url"
+)
+
+const messagingServicesPathPart = "Services"
+
+// MessagingServiceService lets you create and manage Messaging Services, and
+// the pools of phone numbers, short codes and alphanumeric sender IDs they
+// send from. See https://www.twilio.com/docs/messaging/services.
+type MessagingServiceService struct {
+	client *Client
+}
+
+// A MessagingService is a container for the senders and settings used to send
+// messages. Pass its Sid as the MessagingServiceSid when sending a Message.
+type MessagingService struct {
+	Sid                       string            `json:"sid"`
+	AccountSid                string            `json:"account_sid"`
+	FriendlyName              string            `json:"friendly_name"`
+	InboundRequestURL         string            `json:"inbound_request_url"`
+	InboundMethod             string            `json:"inbound_method"`
+	FallbackURL               string            `json:"fallback_url"`
+	FallbackMethod            string            `json:"fallback_method"`
+	StatusCallback            string            `json:"status_callback"`
+	StickySender              bool              `json:"sticky_sender"`
+	MmsConverter              bool              `json:"mms_converter"`
+	SmartEncoding             bool              `json:"smart_encoding"`
+	ScanMessageContent        string            `json:"scan_message_content"`
+	FallbackToLongCode        bool              `json:"fallback_to_long_code"`
+	AreaCodeGeomatch          bool              `json:"area_code_geomatch"`
+	ValidityPeriod            int               `json:"validity_period"`
+	SynchronousValidation     bool              `json:"synchronous_validation"`
+	Usecase                   string            `json:"usecase"`
+	UseInboundWebhookOnNumber bool              `json:"use_inbound_webhook_on_number"`
+	DateCreated               TwilioTime        `json:"date_created"`
+	DateUpdated               TwilioTime        `json:"date_updated"`
+	URL                       string            `json:"url"`
+	Links                     map[string]string `json:"links"`
+}
+
+// MessagingServicePage represents a page of Messaging Services.
+type MessagingServicePage struct {
+	Meta     Meta                `json:"meta"`
+	Services []*MessagingService `json:"services"`
+}
+
+// Create creates a new Messaging Service. FriendlyName is required.
+//
+// For a list of valid parameters see
+// https://www.twilio.com/docs/messaging/api/service-resource#create-a-service-resource.
+func (s *MessagingServiceService) Create(ctx context.Context, data url.Values) (*MessagingService, error) {
+	service := new(MessagingService)
+	err := s.client.CreateResource(ctx, messagingServicesPathPart, data, service)
+	return service, err
+}
+
+// Get retrieves a Messaging Service by its sid.
+func (s *MessagingServiceService) Get(ctx context.Context, sid string) (*MessagingService, error) {
+	service := new(MessagingService)
+	err := s.client.GetResource(ctx, messagingServicesPathPart, sid, service)
+	return service, err
+}
+
+// Update updates the Messaging Service with the given sid.
+//
+// For a list of valid parameters see
+// https://www.twilio.com/docs/messaging/api/service-resource#update-a-service-resource.
+func (s *MessagingServiceService) Update(ctx context.Context, sid string, data url.Values) (*MessagingService, error) {
+	service := new(MessagingService)
+	err := s.client.UpdateResource(ctx, messagingServicesPathPart, sid, data, service)
+	return service, err
+}
+
+// Delete deletes the Messaging Service with the given sid. Its senders are
+// removed from the service, but not released from your account.
+func (s *MessagingServiceService) Delete(ctx context.Context, sid string) error {
+	return s.client.DeleteResource(ctx, messagingServicesPathPart, sid)
+}
+
+// GetPage returns a single Page of Messaging Services, filtered by data.
+func (s *MessagingServiceService) GetPage(ctx context.Context, data url.Values) (*MessagingServicePage, error) {
+	return s.GetPageIterator(data).Next(ctx)
+}
+
+// MessagingServicePageIterator lets you retrieve consecutive pages of
+// Messaging Services.
+type MessagingServicePageIterator struct {
+	p *PageIterator
+}
+
+// GetPageIterator returns a MessagingServicePageIterator with the given page
+// filters. Call iterator.Next() to get the first page of resources (and again
+// to retrieve subsequent pages).
+func (s *MessagingServiceService) GetPageIterator(data url.Values) *MessagingServicePageIterator {
+	return &MessagingServicePageIterator{
+		p: NewPageIterator(s.client, data, messagingServicesPathPart),
+	}
+}
+
+// Next returns the next page of resources. If there are no more resources,
+// NoMoreResults is returned.
+func (s *MessagingServicePageIterator) Next(ctx context.Context) (*MessagingServicePage, error) {
+	sp := new(MessagingServicePage)
+	err := s.p.Next(ctx, sp)
+	if err != nil {
+		return nil, err
+	}
+	s.p.SetNextPageURI(sp.Meta.NextPageURL)
+	return sp, nil
+}
+
+// PhoneNumbers returns a service for managing the phone numbers in the sender
+// pool of the Messaging Service with the given sid.
+func (s *MessagingServiceService) PhoneNumbers(serviceSid string) *ServicePhoneNumberService {
+	return &ServicePhoneNumberService{client: s.client, pathPart: servicePoolPathPart(serviceSid, "PhoneNumbers")}
+}
+
+// ShortCodes returns a service for managing the short codes in the sender
+// pool of the Messaging Service with the given sid.
+func (s *MessagingServiceService) ShortCodes(serviceSid string) *ServiceShortCodeService {
+	return &ServiceShortCodeService{client: s.client, pathPart: servicePoolPathPart(serviceSid, "ShortCodes")}
+}
+
+// AlphaSenders returns a service for managing the alphanumeric sender IDs of
+// the Messaging Service with the given sid.
+func (s *MessagingServiceService) AlphaSenders(serviceSid string) *AlphaSenderService {
+	return &AlphaSenderService{client: s.client, pathPart: servicePoolPathPart(serviceSid, "AlphaSenders")}
+}
+
+func servicePoolPathPart(serviceSid string, pool string) string {
+	return messagingServicesPathPart + "/" + serviceSid + "/" + pool
+}
+
+// ServicePhoneNumberService manages the phone numbers in a Messaging
+// Service's sender pool.
+type ServicePhoneNumberService struct {
+	client   *Client
+	pathPart string
+}
+
+// A ServicePhoneNumber is a phone number in a Messaging Service's sender pool.
+// Its Sid is the sid of the IncomingPhoneNumber.
+type ServicePhoneNumber struct {
+	Sid          string      `json:"sid"`
+	AccountSid   string      `json:"account_sid"`
+	ServiceSid   string      `json:"service_sid"`
+	PhoneNumber  PhoneNumber `json:"phone_number"`
+	CountryCode  string      `json:"country_code"`
+	Capabilities []string    `json:"capabilities"`
+	DateCreated  TwilioTime  `json:"date_created"`
+	DateUpdated  TwilioTime  `json:"date_updated"`
+	URL          string      `json:"url"`
+}
+
+// ServicePhoneNumberPage represents a page of ServicePhoneNumbers.
+type ServicePhoneNumberPage struct {
+	Meta         Meta                  `json:"meta"`
+	PhoneNumbers []*ServicePhoneNumber `json:"phone_numbers"`
+}
+
+// Add adds the IncomingPhoneNumber with the given sid (e.g. "PN123") to the
+// sender pool. A phone number can only be in one Messaging Service.
+func (s *ServicePhoneNumberService) Add(ctx context.Context, phoneNumberSid string) (*ServicePhoneNumber, error) {
+	number := new(ServicePhoneNumber)
+	data := url.Values{"PhoneNumberSid": []string{phoneNumberSid}}
+	err := s.client.CreateResource(ctx, s.pathPart, data, number)
+	return number, err
+}
+
+// Get retrieves the phone number with the given sid from the sender pool.
+func (s *ServicePhoneNumberService) Get(ctx context.Context, sid string) (*ServicePhoneNumber, error) {
+	number := new(ServicePhoneNumber)
+	err := s.client.GetResource(ctx, s.pathPart, sid, number)
+	return number, err
+}
+
+// Remove removes the phone number with the given sid from the sender pool. The
+// number is not released from your account.
+func (s *ServicePhoneNumberService) Remove(ctx context.Context, sid string) error {
+	return s.client.DeleteResource(ctx, s.pathPart, sid)
+}
+
+// GetPage returns a single Page of the phone numbers in the sender pool.
+func (s *ServicePhoneNumberService) GetPage(ctx context.Context, data url.Values) (*ServicePhoneNumberPage, error) {
+	return s.GetPageIterator(data).Next(ctx)
+}
+
+// ServicePhoneNumberPageIterator lets you retrieve consecutive pages of
+// ServicePhoneNumbers.
+type ServicePhoneNumberPageIterator struct {
+	p *PageIterator
+}
+
+// GetPageIterator returns a ServicePhoneNumberPageIterator with the given page
+// filters. Call iterator.Next() to get the first page of resources (and again
+// to retrieve subsequent pages).
+func (s *ServicePhoneNumberService) GetPageIterator(data url.Values) *ServicePhoneNumberPageIterator {
+	return &ServicePhoneNumberPageIterator{
+		p: NewPageIterator(s.client, data, s.pathPart),
+	}
+}
+
+// Next returns the next page of resources. If there are no more resources,
+// NoMoreResults is returned.
+func (s *ServicePhoneNumberPageIterator) Next(ctx context.Context) (*ServicePhoneNumberPage, error) {
+	np := new(ServicePhoneNumberPage)
+	err := s.p.Next(ctx, np)
+	if err != nil {
+		return nil, err
+	}
+	s.p.SetNextPageURI(np.Meta.NextPageURL)
+	return np, nil
+}
+
+// ServiceShortCodeService manages the short codes in a Messaging Service's
+// sender pool.
+type ServiceShortCodeService struct {
+	client   *Client
+	pathPart string
+}
+
+// A ServiceShortCode is a short code in a Messaging Service's sender pool. Its
+// Sid is the sid of the short code.
+type ServiceShortCode struct {
+	Sid          string     `json:"sid"`
+	AccountSid   string     `json:"account_sid"`
+	ServiceSid   string     `json:"service_sid"`
+	ShortCode    string     `json:"short_code"`
+	CountryCode  string     `json:"country_code"`
+	Capabilities []string   `json:"capabilities"`
+	DateCreated  TwilioTime `json:"date_created"`
+	DateUpdated  TwilioTime `json:"date_updated"`
+	URL          string     `json:"url"`
+}
+
+// ServiceShortCodePage represents a page of ServiceShortCodes.
+type ServiceShortCodePage struct {
+	Meta       Meta                `json:"meta"`
+	ShortCodes []*ServiceShortCode `json:"short_codes"`
+}
+
+// Add adds the short code with the given sid (e.g. "SC123") to the sender
+// pool.
+func (s *ServiceShortCodeService) Add(ctx context.Context, shortCodeSid string) (*ServiceShortCode, error) {
+	code := new(ServiceShortCode)
+	data := url.Values{"ShortCodeSid": []string{shortCodeSid}}
+	err := s.client.CreateResource(ctx, s.pathPart, data, code)
+	return code, err
+}
+
+// Get retrieves the short code with the given sid from the sender pool.
+func (s *ServiceShortCodeService) Get(ctx context.Context, sid string) (*ServiceShortCode, error) {
+	code := new(ServiceShortCode)
+	err := s.client.GetResource(ctx, s.pathPart, sid, code)
+	return code, err
+}
+
+// Remove removes the short code with the given sid from the sender pool.
+func (s *ServiceShortCodeService) Remove(ctx context.Context, sid string) error {
+	return s.client.DeleteResource(ctx, s.pathPart, sid)
+}
+
+// GetPage returns a single Page of the short codes in the sender pool.
+func (s *ServiceShortCodeService) GetPage(ctx context.Context, data url.Values) (*ServiceShortCodePage, error) {
+	return s.GetPageIterator(data).Next(ctx)
+}
+
+// ServiceShortCodePageIterator lets you retrieve consecutive pages of
+// ServiceShortCodes.
+type ServiceShortCodePageIterator struct {
+	p *PageIterator
+}
+
+// GetPageIterator returns a ServiceShortCodePageIterator with the given page
+// filters. Call iterator.Next() to get the first page of resources (and again
+// to retrieve subsequent pages).
+func (s *ServiceShortCodeService) GetPageIterator(data url.Values) *ServiceShortCodePageIterator {
+	return &ServiceShortCodePageIterator{
+		p: NewPageIterator(s.client, data, s.pathPart),
+	}
+}
+
+// Next returns the next page of resources. If there are no more resources,
+// NoMoreResults is returned.
+func (s *ServiceShortCodePageIterator) Next(ctx context.Context) (*ServiceShortCodePage, error) {
+	cp := new(ServiceShortCodePage)
+	err := s.p.Next(ctx, cp)
+	if err != nil {
+		return nil, err
+	}
+	s.p.SetNextPageURI(cp.Meta.NextPageURL)
+	return cp, nil
+}
+
+// AlphaSenderService manages the alphanumeric sender IDs of a Messaging
+// Service.
+type AlphaSenderService struct {
+	client   *Client
+	pathPart string
+}
+
+// An AlphaSender is an alphanumeric sender ID, like "MyCompany", that a
+// Messaging Service sends from in countries that support it.
+type AlphaSender struct {
+	Sid          string     `json:"sid"`
+	AccountSid   string     `json:"account_sid"`
+	ServiceSid   string     `json:"service_sid"`
+	AlphaSender  string     `json:"alpha_sender"`
+	Capabilities []string   `json:"capabilities"`
+	DateCreated  TwilioTime `json:"date_created"`
+	DateUpdated  TwilioTime `json:"date_updated"`
+	URL          string     `json:"url"`
+}
+
+// AlphaSenderPage represents a page of AlphaSenders.
+type AlphaSenderPage struct {
+	Meta         Meta           `json:"meta"`
+	AlphaSenders []*AlphaSender `json:"alpha_senders"`
+}
+
+// Add adds an alphanumeric sender ID of up to 11 characters, e.g.
+// "MyCompany", to the Messaging Service.
+func (s *AlphaSenderService) Add(ctx context.Context, alphaSender string) (*AlphaSender, error) {
+	sender := new(AlphaSender)
+	data := url.Values{"AlphaSender": []string{alphaSender}}
+	err := s.client.CreateResource(ctx, s.pathPart, data, sender)
+	return sender, err
+}
+
+// Get retrieves the alphanumeric sender ID with the given sid (e.g. "AI123").
+func (s *AlphaSenderService) Get(ctx context.Context, sid string) (*AlphaSender, error) {
+	sender := new(AlphaSender)
+	err := s.client.GetResource(ctx, s.pathPart, sid, sender)
+	return sender, err
+}
+
+// Remove removes the alphanumeric sender ID with the given sid from the
+// Messaging Service.
+func (s *AlphaSenderService) Remove(ctx context.Context, sid string) error {
+	return s.client.DeleteResource(ctx, s.pathPart, sid)
+}
+
+// GetPage returns a single Page of the Messaging Service's alphanumeric
+// sender IDs.
+func (s *AlphaSenderService) GetPage(ctx context.Context, data url.Values) (*AlphaSenderPage, error) {
+	return s.GetPageIterator(data).Next(ctx)
+}
+
+// AlphaSenderPageIterator lets you retrieve consecutive pages of
+// AlphaSenders.
+type AlphaSenderPageIterator struct {
+	p *PageIterator
+}
+
+// GetPageIterator returns an AlphaSenderPageIterator with the given page
+// filters. Call iterator.Next() to get the first page of resources (and again
+// to retrieve subsequent pages).
+func (s *AlphaSenderService) GetPageIterator(data url.Values) *AlphaSenderPageIterator {
+	return &AlphaSenderPageIterator{
+		p: NewPageIterator(s.client, data, s.pathPart),
+	}
+}
+
+// Next returns the next page of resources. If there are no more resources,
+// NoMoreResults is returned.
+func (s *AlphaSenderPageIterator) Next(ctx context.Context) (*AlphaSenderPage, error) {
+	ap := new(AlphaSenderPage)
+	err := s.p.Next(ctx, ap)
+	if err != nil {
+		return nil, err
+	}
+	s.p.SetNextPageURI(ap.Meta.NextPageURL)
+	return ap, nil
+}
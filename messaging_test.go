@@ -0,0 +1,112 @@
+package twilio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestMessagingServiceGet(t *testing.T) {
+	t.Parallel()
+	client, s := getServer(messagingServiceResponse)
+	defer s.Close()
+	service, err := client.MessagingServices.Services.Get(context.Background(), "MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service.Sid != "MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa" || service.FriendlyName != "Notifications" {
+		t.Errorf("bad service: %#v", service)
+	}
+	if !service.StickySender || service.ValidityPeriod != 600 || service.Links["phone_numbers"] == "" {
+		t.Errorf("bad service settings: %#v", service)
+	}
+	if !service.DateCreated.Valid {
+		t.Error("expected DateCreated to be valid")
+	}
+	if path := s.URLs[0].Path; path != "/v1/Services/MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa" {
+		t.Errorf("bad path: %s", path)
+	}
+}
+
+func TestMessagingServicePhoneNumbers(t *testing.T) {
+	t.Parallel()
+	client, s := getServer(servicePhoneNumberPageResponse)
+	defer s.Close()
+	page, err := client.MessagingServices.Services.PhoneNumbers("MG123").GetPage(context.Background(), url.Values{"PageSize": []string{"50"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.PhoneNumbers) != 1 {
+		t.Fatalf("expected 1 phone number, got %d", len(page.PhoneNumbers))
+	}
+	if pn := page.PhoneNumbers[0]; pn.PhoneNumber != "+19253920364" || len(pn.Capabilities) != 2 {
+		t.Errorf("bad phone number: %#v", pn)
+	}
+	if page.Meta.Key != "phone_numbers" {
+		t.Errorf("bad meta key: %s", page.Meta.Key)
+	}
+	if path := s.URLs[0].Path; path != "/v1/Services/MG123/PhoneNumbers" {
+		t.Errorf("bad path: %s", path)
+	}
+}
+
+func TestMessagingServiceSenderPools(t *testing.T) {
+	t.Parallel()
+	type request struct {
+		method, path string
+		form         url.Values
+	}
+	var requests []request
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		requests = append(requests, request{r.Method, r.URL.Path, r.PostForm})
+		if r.Method == "DELETE" {
+			w.WriteHeader(204)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(201)
+		w.Write(alphaSenderResponse)
+	}))
+	defer s.Close()
+	client := NewClientWithOptions("AC123", "456", WithBaseURL(ProductMessaging, s.URL))
+	ctx := context.Background()
+	services := client.MessagingServices.Services
+	if _, err := services.PhoneNumbers("MG123").Add(ctx, "PN123"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := services.ShortCodes("MG123").Add(ctx, "SC123"); err != nil {
+		t.Fatal(err)
+	}
+	sender, err := services.AlphaSenders("MG123").Add(ctx, "Twilio")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender.AlphaSender != "Twilio" || sender.ServiceSid != "MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa" {
+		t.Errorf("bad alpha sender: %#v", sender)
+	}
+	if err := services.ShortCodes("MG123").Remove(ctx, "SC123"); err != nil {
+		t.Fatal(err)
+	}
+	if err := services.Delete(ctx, "MG123"); err != nil {
+		t.Fatal(err)
+	}
+	want := []request{
+		{"POST", "/v1/Services/MG123/PhoneNumbers", url.Values{"PhoneNumberSid": {"PN123"}}},
+		{"POST", "/v1/Services/MG123/ShortCodes", url.Values{"ShortCodeSid": {"SC123"}}},
+		{"POST", "/v1/Services/MG123/AlphaSenders", url.Values{"AlphaSender": {"Twilio"}}},
+		{"DELETE", "/v1/Services/MG123/ShortCodes/SC123", url.Values{}},
+		{"DELETE", "/v1/Services/MG123", url.Values{}},
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("expected %d requests, got %d", len(want), len(requests))
+	}
+	for i := range want {
+		got := requests[i]
+		if got.method != want[i].method || got.path != want[i].path || got.form.Encode() != want[i].form.Encode() {
+			t.Errorf("request %d: got %v, want %v", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package twilio
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// A Request is an API request, as seen by a Middleware.
+type Request struct {
+	// Method is the HTTP method, e.g. "GET" or "POST".
+	Method string
+	// Path is the resource path without the query string, e.g.
+	// "/2010-04-01/Accounts/AC123/Calls.json".
+	Path string
+	// Data holds the form body of a POST request, or the query parameters of
+	// a GET request. It's nil for Media requests. Middleware should not modify
+	// it.
+	Data url.Values
+	// AccountSid is the Account the request is made for.
+	AccountSid string
+	// IdempotencyKey is the key sent in the IdempotencyHeader, if the
+	// request was made with a Context from WithIdempotencyKey.
+	IdempotencyKey string
+	// HTTPRequest is the request that will be sent. Middleware may add or
+	// change its headers.
+	HTTPRequest *http.Request
+}
+
+// A Handler sends a Request. If the API returns an error status, the Handler
+// returns the response along with the decoded error, usually a
+// *resterror.Error or an *Error. In either case the response body has not been
+// read yet.
+type Handler func(*Request) (*http.Response, error)
+
+// A Middleware wraps the Handler that sends each request. It can inspect or
+// change the request before calling next, and inspect the response and error
+// that next returns. Add Middleware to a Client with Use.
+type Middleware func(next Handler) Handler
+
+// Use adds middleware to the chain that wraps every request made by the
+// Client, and every product Client created by NewClient (Monitor, Pricing,
+// Wireless, etc). This includes requests made by MakeRequest, GetNextPage and
+// the Media service. The first Middleware added is the outermost, so it sees
+// each request first and each response last. If a RetryPolicy is set, the
+// chain runs once per attempt.
+//
+// Use is not thread safe; call it before making any requests.
+func (c *Client) Use(middleware ...Middleware) {
+	c.middleware = append(c.middleware, middleware...)
+	c.forgetAccounts()
+	for _, sub := range c.subClients() {
+		sub.Use(middleware...)
+	}
+}
+
+// send runs r through the Client's middleware, and then h. If the Client has a
+// Logger, the request is logged after it leaves the middleware.
+func (c *Client) send(r *Request, h Handler) (*http.Response, error) {
+	if c.Logger != nil {
+		h = logRequests(c.Logger, h)
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h(r)
+}
+
+// roundTrip is the innermost Handler for API requests. It sends the request
+// with the Client's http.Client, and decodes error responses.
+func (c *Client) roundTrip(r *Request) (*http.Response, error) {
+	client := c.Client.Client
+	if client == nil {
+		client = defaultHttpClient
+	}
+	resp, err := client.Do(r.HTTPRequest)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 400 {
+		return resp, nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return resp, err
+	}
+	parse := c.ErrorParser
+	if parse == nil {
+		parse = parseTwilioError
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	err = parse(resp)
+	// Leave the body for middleware further up the chain.
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return resp, err
+}
+
+// newRequest returns the Request for an HTTP request to pathPart, which may
+// include a query string.
+func newRequest(req *http.Request, pathPart string, data url.Values) *Request {
+	r := &Request{Method: req.Method, Path: pathPart, Data: data, HTTPRequest: req}
+	if i := strings.IndexByte(pathPart, '?'); i >= 0 {
+		r.Path = pathPart[:i]
+		if data == nil {
+			// A next page URI.
+			r.Data, _ = url.ParseQuery(pathPart[i+1:])
+		}
+	}
+	return r
+}
+
+// DumpTraffic returns a Middleware that writes every request and response,
+// including their bodies, to w. The Authorization header is redacted, but
+// message bodies and phone numbers are not; to log requests in production, use
+// Client.SetLogger instead. Use DumpTraffic for debugging:
+//
+//	client.Use(twilio.DumpTraffic(os.Stderr))
+func DumpTraffic(w io.Writer) Middleware {
+	var mu sync.Mutex
+	write := func(bits []byte) {
+		if len(bits) > 0 && bits[len(bits)-1] != '\n' {
+			bits = append(bits, '\n')
+		}
+		mu.Lock()
+		w.Write(bits)
+		mu.Unlock()
+	}
+	return func(next Handler) Handler {
+		return func(r *Request) (*http.Response, error) {
+			req := r.HTTPRequest
+			if req.Header.Get("Authorization") != "" {
+				req = req.Clone(req.Context())
+				req.Header.Set("Authorization", "[redacted]")
+			}
+			// DumpRequestOut restores the request body after reading it.
+			if bits, err := httputil.DumpRequestOut(req, true); err == nil {
+				if req != r.HTTPRequest {
+					r.HTTPRequest.Body = req.Body
+				}
+				write(bits)
+			}
+			resp, err := next(r)
+			if resp != nil {
+				if bits, dumpErr := httputil.DumpResponse(resp, true); dumpErr == nil {
+					write(bits)
+				}
+			}
+			return resp, err
+		}
+	}
+}
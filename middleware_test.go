@@ -0,0 +1,164 @@
+package twilio
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if r.Header.Get("X-Tenant") != "acme" {
+			w.WriteHeader(400)
+			w.Write([]byte(`{"code": 20001, "message": "missing header", "status": 400}`))
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/CAnotfound.json") || strings.HasPrefix(r.URL.Path, "/v1/") {
+			w.WriteHeader(404)
+			w.Write([]byte(`{"code": 20404, "message": "The requested resource was not found", "status": 404}`))
+			return
+		}
+		w.WriteHeader(200)
+		w.Write(makeCallResponse)
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	client.Wireless.Base = s.URL
+
+	var mu sync.Mutex
+	var seen []*Request
+	var errs []error
+	var order []string
+	client.Use(func(next Handler) Handler {
+		return func(r *Request) (*http.Response, error) {
+			order = append(order, "outer")
+			r.HTTPRequest.Header.Set("X-Tenant", "acme")
+			return next(r)
+		}
+	}, func(next Handler) Handler {
+		return func(r *Request) (*http.Response, error) {
+			order = append(order, "inner")
+			resp, err := next(r)
+			mu.Lock()
+			seen = append(seen, r)
+			errs = append(errs, err)
+			mu.Unlock()
+			return resp, err
+		}
+	})
+
+	data := url.Values{}
+	data.Set("From", from)
+	data.Set("To", to)
+	data.Set("Url", "https://kev.inburke.com/zombo/zombocom.mp3")
+	if _, err := client.Calls.Create(context.Background(), data); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Calls.Get(context.Background(), "CAnotfound"); !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if err := client.GetNextPage(context.Background(), "/2010-04-01/Accounts/AC123/Calls.json?Page=1&PageSize=50", new(CallPage)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Wireless.Sims.Get(context.Background(), "DEadbeef"); !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	if len(seen) != 4 {
+		t.Fatalf("expected middleware to see 4 requests, got %d", len(seen))
+	}
+	if want := []string{"outer", "inner", "outer", "inner"}; strings.Join(order[:4], " ") != strings.Join(want, " ") {
+		t.Errorf("bad middleware order: %v", order)
+	}
+	if r := seen[0]; r.Method != "POST" || r.Path != "/2010-04-01/Accounts/AC123/Calls.json" || r.Data.Get("To") != to {
+		t.Errorf("bad create request: %#v", r)
+	}
+	if errs[0] != nil {
+		t.Errorf("expected no error for create, got %v", errs[0])
+	}
+	if !IsNotFound(errs[1]) {
+		t.Errorf("expected middleware to see the decoded error, got %v", errs[1])
+	}
+	if r := seen[2]; r.Method != "GET" || r.Path != "/2010-04-01/Accounts/AC123/Calls.json" || r.Data.Get("PageSize") != "50" {
+		t.Errorf("bad next page request: %#v", r)
+	}
+	if r := seen[3]; r.Path != "/v1/Sims/DEadbeef" {
+		t.Errorf("expected middleware to run for product clients, got path %q", r.Path)
+	}
+}
+
+func TestMiddlewareMedia(t *testing.T) {
+	t.Parallel()
+	const location = "https://s3-external-1.amazonaws.com/media.twiliocdn.com/AC123/a1b2c3"
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Tenant") != "acme" {
+			w.WriteHeader(400)
+			return
+		}
+		w.Header().Set("Location", location)
+		w.WriteHeader(http.StatusFound)
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	var paths []string
+	client.Use(func(next Handler) Handler {
+		return func(r *Request) (*http.Response, error) {
+			paths = append(paths, r.Path)
+			r.HTTPRequest.Header.Set("X-Tenant", "acme")
+			return next(r)
+		}
+	})
+	u, err := client.Media.GetURL(context.Background(), "MM123", "ME456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.String() != location {
+		t.Errorf("bad media URL: %s", u)
+	}
+	if len(paths) != 1 || paths[0] != "/2010-04-01/Accounts/AC123/Messages/MM123/Media/ME456" {
+		t.Errorf("bad media paths: %v", paths)
+	}
+}
+
+func TestDumpTraffic(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("To") != to {
+			w.WriteHeader(400)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(201)
+		w.Write(makeCallResponse)
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	buf := new(bytes.Buffer)
+	client.Use(DumpTraffic(buf))
+	call, err := client.Calls.MakeCall(from, to, &url.URL{Scheme: "https", Host: "example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Sid != "CA47b862ce3b99a6d79939320a9aa54a02" {
+		t.Errorf("wrong sid: %s", call.Sid)
+	}
+	out := buf.String()
+	for _, want := range []string{"POST /2010-04-01/Accounts/AC123/Calls.json", "To=%2B19253920364", "HTTP/1.1 201 Created", `"sid": "CA47b862ce3b99a6d79939320a9aa54a02"`, "Authorization: [redacted]"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected dump to contain %q, got\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Basic ") {
+		t.Errorf("expected dump to redact credentials, got\n%s", out)
+	}
+}
@@ -0,0 +1,237 @@
+package twilio
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/kevinburke/rest/restclient"
+)
+
+// A Product is a Twilio API, named by the subdomain that serves it, e.g.
+// "api" for api.twilio.com.
+type Product string
+
+const (
+	ProductAPI        Product = "api"
+	ProductMonitor    Product = "monitor"
+	ProductPricing    Product = "pricing"
+	ProductFax        Product = "fax"
+	ProductWireless   Product = "wireless"
+	ProductNotify     Product = "notify"
+	ProductLookup     Product = "lookups"
+	ProductVerify     Product = "verify"
+	ProductVideo      Product = "video"
+	ProductTaskRouter Product = "taskrouter"
+	ProductInsights   Product = "insights"
+	ProductSuperSim   Product = "supersim"
+	ProductMessaging  Product = "messaging"
+)
+
+// defaultBaseURL returns the package level base URL for p, e.g. BaseURL or
+// MonitorBaseURL.
+func defaultBaseURL(p Product) string {
+	switch p {
+	case ProductMonitor:
+		return MonitorBaseURL
+	case ProductPricing:
+		return PricingBaseURL
+	case ProductFax:
+		return FaxBaseURL
+	case ProductWireless:
+		return WirelessBaseURL
+	case ProductNotify:
+		return NotifyBaseURL
+	case ProductLookup:
+		return LookupBaseURL
+	case ProductVerify:
+		return VerifyBaseURL
+	case ProductVideo:
+		return VideoBaseUrl
+	case ProductTaskRouter:
+		return TaskRouterBaseUrl
+	case ProductInsights:
+		return InsightsBaseUrl
+	case ProductSuperSim:
+		return SuperSimBaseUrl
+	case ProductMessaging:
+		return MessagingBaseURL
+	default:
+		return BaseURL
+	}
+}
+
+// An Option configures a Client created with NewClientWithOptions.
+type Option func(*options)
+
+type options struct {
+	baseURLs        map[Product]string
+	region          string
+	edge            string
+	httpClient      *http.Client
+	timeout         time.Duration
+	transport       http.RoundTripper
+	userAgentSuffix string
+	credentials     CredentialProvider
+	logger          *slog.Logger
+}
+
+// WithBaseURL sets the base URL for a product, e.g. WithBaseURL(ProductAPI,
+// server.URL) to send API requests to a test server. It overrides WithRegion
+// and WithEdge for that product.
+func WithBaseURL(p Product, baseURL string) Option {
+	return func(o *options) {
+		if o.baseURLs == nil {
+			o.baseURLs = make(map[Product]string)
+		}
+		o.baseURLs[p] = baseURL
+	}
+}
+
+// WithRegion sends requests to a Twilio Region, e.g. "ie1" or "au1". If no
+// Edge is set, the Region's default Edge is used. See
+// https://www.twilio.com/docs/global-infrastructure.
+func WithRegion(region string) Option {
+	return func(o *options) {
+		o.region = region
+	}
+}
+
+// WithEdge sends requests through a Twilio Edge location, e.g. "dublin" or
+// "sydney". If no Region is set, "us1" is used. WithRegion("ie1") and
+// WithEdge("dublin") send API requests to https://api.dublin.ie1.twilio.com.
+func WithEdge(edge string) Option {
+	return func(o *options) {
+		o.edge = edge
+	}
+}
+
+// WithHTTPClient sets the http.Client used to make requests. WithTimeout and
+// WithTransport modify a copy of it.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithTimeout sets the timeout for each request, including reading the
+// response body. The default is a little over 30 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithTransport sets the RoundTripper used to make requests, for example a
+// proxy or a twiliotest.Recorder.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+// WithUserAgentSuffix appends suffix, e.g. "myapp/1.2", to the User-Agent
+// header of each request.
+func WithUserAgentSuffix(suffix string) Option {
+	return func(o *options) {
+		o.userAgentSuffix = suffix
+	}
+}
+
+// WithCredentialProvider authenticates every request with the Credentials
+// from p, instead of the Account Sid and Auth Token.
+func WithCredentialProvider(p CredentialProvider) Option {
+	return func(o *options) {
+		o.credentials = p
+	}
+}
+
+// WithAPIKey authenticates every request with an API Key Sid and secret.
+// The Account Sid is still used in the URL of api.twilio.com resources, and
+// the Auth Token passed to NewClientWithOptions may be empty.
+func WithAPIKey(keySid string, secret string) Option {
+	return WithCredentialProvider(APIKey(keySid, secret))
+}
+
+// WithLogger logs each request to l; see Client.SetLogger.
+func WithLogger(l *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = l
+	}
+}
+
+func (o *options) baseURL(p Product) string {
+	if u, ok := o.baseURLs[p]; ok {
+		return u
+	}
+	if o.region == "" && o.edge == "" {
+		return defaultBaseURL(p)
+	}
+	region := o.region
+	if region == "" {
+		region = "us1"
+	}
+	host := string(p)
+	if o.edge != "" {
+		host += "." + o.edge
+	}
+	return "https://" + host + "." + region + ".twilio.com"
+}
+
+// client returns the http.Client to use, or nil to use the default.
+func (o *options) client() *http.Client {
+	if o.timeout == 0 && o.transport == nil {
+		return o.httpClient
+	}
+	hc := http.Client{Timeout: defaultTimeout, Transport: restclient.DefaultTransport}
+	if o.httpClient != nil {
+		hc = *o.httpClient
+	}
+	if o.timeout > 0 {
+		hc.Timeout = o.timeout
+	}
+	if o.transport != nil {
+		hc.Transport = o.transport
+	}
+	return &hc
+}
+
+// NewClientWithOptions creates a Client for interacting with the Twilio API,
+// like NewClient, configured with opts. The options apply to the Client and to
+// every product Client it creates (Monitor, Pricing, Wireless, etc), and
+// don't depend on package level variables like BaseURL, so Clients in the same
+// process can use different Regions or servers:
+//
+//	client := twilio.NewClientWithOptions(sid, token,
+//		twilio.WithRegion("ie1"),
+//		twilio.WithEdge("dublin"),
+//		twilio.WithTimeout(10*time.Second),
+//	)
+func NewClientWithOptions(accountSid string, authToken string, opts ...Option) *Client {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	c := newClient(accountSid, authToken, o.client(), o.baseURL)
+	if o.userAgentSuffix != "" {
+		c.userAgentSuffix = o.userAgentSuffix
+		for _, sub := range c.subClients() {
+			sub.userAgentSuffix = o.userAgentSuffix
+		}
+	}
+	if o.credentials != nil {
+		c.SetCredentialProvider(o.credentials)
+	}
+	if o.logger != nil {
+		c.SetLogger(o.logger)
+	}
+	return c
+}
+
+// userAgentHeader returns the User-Agent for requests made by c.
+func (c *Client) userAgentHeader() string {
+	if c.userAgentSuffix == "" {
+		return userAgent
+	}
+	return userAgent + " " + c.userAgentSuffix
+}
@@ -0,0 +1,94 @@
+package twilio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewClientWithOptionsRegion(t *testing.T) {
+	t.Parallel()
+	dublin := NewClientWithOptions("AC123", "456", WithRegion("ie1"), WithEdge("dublin"))
+	sydney := NewClientWithOptions("AC123", "456", WithRegion("au1"))
+	if dublin.Base != "https://api.dublin.ie1.twilio.com" {
+		t.Errorf("bad API base URL: %s", dublin.Base)
+	}
+	if dublin.Wireless.Base != "https://wireless.dublin.ie1.twilio.com" {
+		t.Errorf("bad Wireless base URL: %s", dublin.Wireless.Base)
+	}
+	if dublin.Lookup.Base != "https://lookups.dublin.ie1.twilio.com" {
+		t.Errorf("bad Lookup base URL: %s", dublin.Lookup.Base)
+	}
+	if sydney.Base != "https://api.au1.twilio.com" || sydney.TaskRouter.Base != "https://taskrouter.au1.twilio.com" {
+		t.Errorf("bad base URLs: %s, %s", sydney.Base, sydney.TaskRouter.Base)
+	}
+	edge := NewClientWithOptions("AC123", "456", WithEdge("tokyo"))
+	if edge.Base != "https://api.tokyo.us1.twilio.com" {
+		t.Errorf("bad API base URL: %s", edge.Base)
+	}
+	if c := NewClientWithOptions("AC123", "456"); c.Base != BaseURL || c.Verify.Base != VerifyBaseURL {
+		t.Errorf("expected default base URLs, got %s, %s", c.Base, c.Verify.Base)
+	}
+}
+
+type countingTransport struct {
+	count int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&c.count, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	t.Parallel()
+	var agents []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents = append(agents, r.Header.Get("User-Agent"))
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if strings.HasPrefix(r.URL.Path, "/v1/Alerts") {
+			w.Write(alertListResponse)
+			return
+		}
+		w.Write(makeCallResponse)
+	}))
+	defer s.Close()
+	transport := new(countingTransport)
+	client := NewClientWithOptions("AC123", "456",
+		WithRegion("ie1"),
+		WithBaseURL(ProductAPI, s.URL),
+		WithBaseURL(ProductMonitor, s.URL),
+		WithTransport(transport),
+		WithTimeout(5*time.Second),
+		WithUserAgentSuffix("myapp/1.2"),
+	)
+	if client.Fax.Base != "https://fax.ie1.twilio.com" {
+		t.Errorf("bad Fax base URL: %s", client.Fax.Base)
+	}
+	if _, err := client.Calls.Get(context.Background(), "CA47b862ce3b99a6d79939320a9aa54a02"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Monitor.Alerts.GetPage(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&transport.count); n != 2 {
+		t.Errorf("expected 2 requests through the transport, got %d", n)
+	}
+	for _, agent := range agents {
+		if !strings.HasPrefix(agent, userAgent) || !strings.Contains(agent, "myapp/1.2") {
+			t.Errorf("bad User-Agent: %q", agent)
+		}
+	}
+	for _, c := range append([]*Client{client}, client.subClients()...) {
+		if c.Client.Client.Timeout != 5*time.Second {
+			t.Errorf("expected 5s timeout for %s, got %v", c.Base, c.Client.Client.Timeout)
+		}
+	}
+	if defaultHttpClient.Timeout != defaultTimeout {
+		t.Errorf("options should not change the default http.Client")
+	}
+}
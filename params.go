@@ -0,0 +1,118 @@
+package twilio
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ttacon/libphonenumber"
+)
+
+// A ValidationError reports a request parameter that is missing or invalid.
+// It's returned by the Validate method of parameter structs like
+// MessageParams, before any request is made.
+type ValidationError struct {
+	// Field is the name of the invalid parameter, as Twilio spells it, e.g.
+	// "To" or "StatusCallback".
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return "twilio: invalid " + e.Field + " parameter: " + e.Message
+}
+
+// validatePhoneNumber returns an error if val is not an E.164 phone number.
+func validatePhoneNumber(field string, val string) error {
+	if val == "" {
+		return &ValidationError{Field: field, Message: "a phone number is required"}
+	}
+	pn, err := NewPhoneNumber(val)
+	if err != nil {
+		return &ValidationError{Field: field, Message: strings.TrimPrefix(err.Error(), "twilio: ")}
+	}
+	if string(pn) != val {
+		return &ValidationError{Field: field, Message: "phone number " + val + " is not in E.164 format, e.g. " + string(pn)}
+	}
+	if num, err := libphonenumber.Parse(val, DefaultRegion); err != nil || !libphonenumber.IsPossibleNumber(num) {
+		return &ValidationError{Field: field, Message: "phone number " + val + " has the wrong number of digits"}
+	}
+	return nil
+}
+
+// validateAddress returns an error if val is not an E.164 phone number. The
+// number in a WhatsApp address, like "whatsapp:+14105551234", is validated;
+// other addresses with a scheme, like "client:alice" or
+// "sip:alice@example.com", are not.
+func validateAddress(field string, val string) error {
+	if i := strings.IndexByte(val, ':'); i >= 0 {
+		if val[:i] != "whatsapp" {
+			return nil
+		}
+		val = val[i+1:]
+	}
+	return validatePhoneNumber(field, val)
+}
+
+// countSet returns the number of non-empty values.
+func countSet(vals ...string) int {
+	n := 0
+	for _, val := range vals {
+		if val != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// exactlyOne returns an error unless exactly one of vals, the values of the
+// parameters with the given names, is set.
+func exactlyOne(names []string, vals ...string) error {
+	if countSet(vals...) != 1 {
+		return &ValidationError{Field: names[0], Message: "exactly one of " + strings.Join(names, ", ") + " must be set"}
+	}
+	return nil
+}
+
+// atMostOne returns an error if more than one of vals, the values of the
+// parameters with the given names, is set.
+func atMostOne(names []string, vals ...string) error {
+	if countSet(vals...) > 1 {
+		return &ValidationError{Field: names[0], Message: "only one of " + strings.Join(names, ", ") + " may be set"}
+	}
+	return nil
+}
+
+// validateJSON returns an error if val is set and is not a JSON object.
+func validateJSON(field string, val string) error {
+	if val == "" {
+		return nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(val), &m); err != nil {
+		return &ValidationError{Field: field, Message: "not a JSON object: " + err.Error()}
+	}
+	return nil
+}
+
+// setString sets key to val in v, if val is not empty.
+func setString(v url.Values, key string, val string) {
+	if val != "" {
+		v.Set(key, val)
+	}
+}
+
+// setInt sets key to val in v, if val is not zero.
+func setInt(v url.Values, key string, val int) {
+	if val != 0 {
+		v.Set(key, strconv.Itoa(val))
+	}
+}
+
+// setBool sets key to "true" or "false" in v, if val is not nil.
+func setBool(v url.Values, key string, val *bool) {
+	if val != nil {
+		v.Set(key, strconv.FormatBool(*val))
+	}
+}
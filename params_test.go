@@ -0,0 +1,161 @@
+package twilio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type validator interface {
+	Validate() error
+}
+
+var validateTests = []struct {
+	name  string
+	p     validator
+	field string // empty if p is valid
+}{
+	{"message", &MessageParams{To: "+14105551234", From: "+19253920364", Body: "hi"}, ""},
+	{"message service", &MessageParams{To: "+14105551234", MessagingServiceSid: "MG123", MediaURLs: []string{"https://example.com/cat.jpg"}}, ""},
+	{"message short code", &MessageParams{To: "+14105551234", From: "894546", Body: "hi"}, ""},
+	{"message whatsapp", &MessageParams{To: "whatsapp:+14105551234", From: "whatsapp:+19253920364", Body: "hi"}, ""},
+	{"message national number", &MessageParams{To: "4105551234", From: "+19253920364", Body: "hi"}, "To"},
+	{"message bad whatsapp", &MessageParams{To: "whatsapp:4105551234", From: "+19253920364", Body: "hi"}, "To"},
+	{"message bad from", &MessageParams{To: "+14105551234", From: "+1 925 392 0364", Body: "hi"}, "From"},
+	{"message no sender", &MessageParams{To: "+14105551234", Body: "hi"}, "From"},
+	{"message no body", &MessageParams{To: "+14105551234", From: "+19253920364"}, "Body"},
+	{"message long body", &MessageParams{To: "+14105551234", From: "+19253920364", Body: strings.Repeat("é", MaxBodyLength+1)}, "Body"},
+	{"message max body", &MessageParams{To: "+14105551234", From: "+19253920364", Body: strings.Repeat("é", MaxBodyLength)}, ""},
+	{"message media", &MessageParams{To: "+14105551234", From: "+19253920364", MediaURLs: make([]string, MaxMediaURLs+1)}, "MediaUrl"},
+	{"message scheduled", &MessageParams{To: "+14105551234", MessagingServiceSid: "MG123", Body: "hi", SendAt: time.Now().Add(time.Hour)}, ""},
+	{"message scheduled no service", &MessageParams{To: "+14105551234", From: "+19253920364", Body: "hi", SendAt: time.Now().Add(time.Hour)}, "MessagingServiceSid"},
+	{"message scheduled too soon", &MessageParams{To: "+14105551234", MessagingServiceSid: "MG123", Body: "hi", SendAt: time.Now().Add(5 * time.Minute)}, "SendAt"},
+	{"message scheduled too late", &MessageParams{To: "+14105551234", MessagingServiceSid: "MG123", Body: "hi", SendAt: time.Now().Add(MaxScheduleDelay + time.Hour)}, "SendAt"},
+	{"call", &CallParams{To: "+14105551234", From: "+19253920364", URL: "https://example.com/twiml"}, ""},
+	{"call client", &CallParams{To: "client:alice", From: "client:bob", ApplicationSid: "AP123"}, ""},
+	{"call no twiml", &CallParams{To: "+14105551234", From: "+19253920364"}, "Url"},
+	{"call url and twiml", &CallParams{To: "+14105551234", From: "+19253920364", URL: "https://example.com/twiml", Twiml: "<Response/>"}, "Url"},
+	{"call bad to", &CallParams{To: "foobarbang", From: "+19253920364", Twiml: "<Response/>"}, "To"},
+	{"call timeout", &CallParams{To: "+14105551234", From: "+19253920364", Twiml: "<Response/>", Timeout: 601}, "Timeout"},
+	{"call timeout low", &CallParams{To: "+14105551234", From: "+19253920364", Twiml: "<Response/>", Timeout: 3}, "Timeout"},
+	{"fax", &FaxParams{To: "+14105551234", From: "+19253920364", MediaURL: "https://example.com/fax.pdf"}, ""},
+	{"fax no media", &FaxParams{To: "+14105551234", From: "+19253920364"}, "MediaUrl"},
+	{"number", &IncomingNumberParams{PhoneNumber: "+14105551234", SmsURL: "https://example.com/sms"}, ""},
+	{"number area code", &IncomingNumberParams{AreaCode: "410"}, ""},
+	{"number none", &IncomingNumberParams{FriendlyName: "main"}, "PhoneNumber"},
+	{"number both", &IncomingNumberParams{PhoneNumber: "+14105551234", AreaCode: "410"}, "PhoneNumber"},
+	{"number voice", &IncomingNumberParams{AreaCode: "410", VoiceURL: "https://example.com/voice", VoiceApplicationSid: "AP123"}, "VoiceUrl"},
+	{"number sms", &IncomingNumberParams{AreaCode: "410", SmsURL: "https://example.com/sms", SmsApplicationSid: "AP123"}, "SmsUrl"},
+	{"activity", &ActivityParams{FriendlyName: "Idle"}, ""},
+	{"activity no name", &ActivityParams{}, "FriendlyName"},
+	{"task queue", &TaskQueueParams{FriendlyName: "support", TaskOrder: "LIFO"}, ""},
+	{"task queue order", &TaskQueueParams{FriendlyName: "support", TaskOrder: "random"}, "TaskOrder"},
+	{"worker", &WorkerParams{FriendlyName: "alice", Attributes: `{"type": "support"}`}, ""},
+	{"worker attributes", &WorkerParams{FriendlyName: "alice", Attributes: `type=support`}, "Attributes"},
+	{"workflow", &WorkflowParams{FriendlyName: "default", Configuration: `{"task_routing": {}}`}, ""},
+	{"workflow no configuration", &WorkflowParams{FriendlyName: "default"}, "Configuration"},
+}
+
+func TestValidateParams(t *testing.T) {
+	t.Parallel()
+	for _, tt := range validateTests {
+		err := tt.p.Validate()
+		if tt.field == "" {
+			if err != nil {
+				t.Errorf("%s: expected nil error, got %v", tt.name, err)
+			}
+			continue
+		}
+		verr, ok := err.(*ValidationError)
+		if !ok {
+			t.Errorf("%s: expected a *ValidationError, got %v", tt.name, err)
+			continue
+		}
+		if verr.Field != tt.field {
+			t.Errorf("%s: expected an error for %s, got %v", tt.name, tt.field, verr)
+		}
+	}
+}
+
+func TestParamsValues(t *testing.T) {
+	t.Parallel()
+	f := false
+	tests := []struct {
+		got  url.Values
+		want url.Values
+	}{
+		{
+			(&MessageParams{To: "+14105551234", From: "+19253920364", Body: "hi", MediaURLs: []string{"a", "b"}, StatusCallback: "https://example.com/cb", ValidityPeriod: 60}).Values(),
+			url.Values{"To": {"+14105551234"}, "From": {"+19253920364"}, "Body": {"hi"}, "MediaUrl": {"a", "b"}, "StatusCallback": {"https://example.com/cb"}, "ValidityPeriod": {"60"}},
+		},
+		{
+			(&MessageParams{To: "+14105551234", MessagingServiceSid: "MG123", Body: "hi", SendAt: time.Date(2030, 1, 2, 3, 4, 5, 0, time.FixedZone("PST", -8*3600))}).Values(),
+			url.Values{"To": {"+14105551234"}, "MessagingServiceSid": {"MG123"}, "Body": {"hi"}, "ScheduleType": {"fixed"}, "SendAt": {"2030-01-02T11:04:05Z"}},
+		},
+		{
+			(&CallParams{To: "+14105551234", From: "+19253920364", URL: "https://example.com/twiml", FallbackURL: "https://example.com/fallback", StatusCallbackEvent: []string{"ringing", "answered"}, Record: true}).Values(),
+			url.Values{"To": {"+14105551234"}, "From": {"+19253920364"}, "Url": {"https://example.com/twiml"}, "FallbackUrl": {"https://example.com/fallback"}, "StatusCallbackEvent": {"ringing", "answered"}, "Record": {"true"}},
+		},
+		{
+			(&FaxParams{To: "+14105551234", MediaURL: "https://example.com/fax.pdf", StoreMedia: &f, TTL: 10}).Values(),
+			url.Values{"To": {"+14105551234"}, "MediaUrl": {"https://example.com/fax.pdf"}, "StoreMedia": {"false"}, "Ttl": {"10"}},
+		},
+		{
+			(&IncomingNumberParams{AreaCode: "410", VoiceURL: "https://example.com/voice", SmsApplicationSid: "AP123"}).Values(),
+			url.Values{"AreaCode": {"410"}, "VoiceUrl": {"https://example.com/voice"}, "SmsApplicationSid": {"AP123"}},
+		},
+		{
+			(&ActivityParams{FriendlyName: "Busy", Available: &f}).Values(),
+			url.Values{"FriendlyName": {"Busy"}, "Available": {"false"}},
+		},
+		{
+			(&WorkflowParams{FriendlyName: "default", Configuration: "{}", AssignmentCallbackURL: "https://example.com/assign"}).Values(),
+			url.Values{"FriendlyName": {"default"}, "Configuration": {"{}"}, "AssignmentCallbackUrl": {"https://example.com/assign"}},
+		},
+	}
+	for i, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%d: got %v, want %v", i, tt.got, tt.want)
+		}
+	}
+}
+
+func TestCreateWithParams(t *testing.T) {
+	t.Parallel()
+	var count int32
+	var form url.Values
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		r.ParseForm()
+		form = r.PostForm
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(201)
+		w.Write(sendMessageResponse)
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	ctx := context.Background()
+	if _, err := client.Messages.CreateWithParams(ctx, &MessageParams{To: "+1410555", From: from, Body: "hi"}); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if n := atomic.LoadInt32(&count); n != 0 {
+		t.Fatalf("expected no requests for invalid params, got %d", n)
+	}
+	msg, err := client.Messages.CreateWithParams(ctx, &MessageParams{To: to, From: from, Body: "hi", StatusCallback: "https://example.com/cb"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Sid == "" {
+		t.Error("expected a message sid")
+	}
+	if form.Get("StatusCallback") != "https://example.com/cb" || form.Get("To") != to || form.Get("Body") != "hi" {
+		t.Errorf("bad form: %v", form)
+	}
+}
@@ -1,21 +1,155 @@
 package twilio
 
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
 // It's difficult to work on this API since Twilio doesn't return Participants
 // after a conference ends.
 //
 // https://github.com/saintpete/logrole/issues/4
 
-type ParticipantService struct{}
+// A ParticipantService lets you list, add, update and remove the Participants
+// in a Conference. Participants are identified by the sid of their Call.
+type ParticipantService struct {
+	client *Client
+}
+
+func participantPathPart(conferenceSid string) string {
+	return conferencePathPart + "/" + conferenceSid + "/Participants"
+}
 
 type Participant struct {
 	AccountSid             string     `json:"account_sid"`
 	CallSid                string     `json:"call_sid"`
+	CallSidToCoach         string     `json:"call_sid_to_coach"`
+	Coaching               bool       `json:"coaching"`
 	ConferenceSid          string     `json:"conference_sid"`
 	DateCreated            TwilioTime `json:"date_created"`
 	DateUpdated            TwilioTime `json:"date_updated"`
 	EndConferenceOnExit    bool       `json:"end_conference_on_exit"`
 	Hold                   bool       `json:"hold"`
+	Label                  string     `json:"label"`
 	Muted                  bool       `json:"muted"`
 	StartConferenceOnEnter bool       `json:"start_conference_on_enter"`
-	URI                    string     `json:"uri"`
+	// One of "queued", "connecting", "ringing", "connected", "complete" or
+	// "failed".
+	Status Status `json:"status"`
+	URI    string `json:"uri"`
+}
+
+type ParticipantPage struct {
+	Page
+	Participants []*Participant `json:"participants"`
+}
+
+// Get returns the Participant in the given Conference with the given Call
+// sid.
+func (p *ParticipantService) Get(ctx context.Context, conferenceSid string, callSid string) (*Participant, error) {
+	participant := new(Participant)
+	err := p.client.GetResource(ctx, participantPathPart(conferenceSid), callSid, participant)
+	return participant, err
+}
+
+// Create dials out to a new Participant and adds them to the Conference. data
+// must include "From" and "To"; for the full list of parameters, see
+// https://www.twilio.com/docs/voice/api/conference-participant-resource#create-a-participant-agent-conference-only.
+func (p *ParticipantService) Create(ctx context.Context, conferenceSid string, data url.Values) (*Participant, error) {
+	participant := new(Participant)
+	err := p.client.CreateResource(ctx, participantPathPart(conferenceSid), data, participant)
+	return participant, err
+}
+
+// Update the Participant with the given data. Valid parameters include
+// "Muted", "Hold", "HoldUrl", "AnnounceUrl", "Coaching" and "CallSidToCoach";
+// see https://www.twilio.com/docs/voice/api/conference-participant-resource#update-a-participant-resource.
+func (p *ParticipantService) Update(ctx context.Context, conferenceSid string, callSid string, data url.Values) (*Participant, error) {
+	participant := new(Participant)
+	err := p.client.UpdateResource(ctx, participantPathPart(conferenceSid), callSid, data, participant)
+	return participant, err
+}
+
+// Mute or unmute the Participant.
+func (p *ParticipantService) Mute(ctx context.Context, conferenceSid string, callSid string, muted bool) (*Participant, error) {
+	data := url.Values{}
+	data.Set("Muted", strconv.FormatBool(muted))
+	return p.Update(ctx, conferenceSid, callSid, data)
+}
+
+// Hold puts the Participant on hold, playing the TwiML at holdURL if it is
+// non-nil, or takes them off hold.
+func (p *ParticipantService) Hold(ctx context.Context, conferenceSid string, callSid string, hold bool, holdURL *url.URL) (*Participant, error) {
+	data := url.Values{}
+	data.Set("Hold", strconv.FormatBool(hold))
+	if hold && holdURL != nil {
+		data.Set("HoldUrl", holdURL.String())
+	}
+	return p.Update(ctx, conferenceSid, callSid, data)
+}
+
+// Announce plays the TwiML at announceURL to the Participant. It returns an
+// error if announceURL is nil.
+func (p *ParticipantService) Announce(ctx context.Context, conferenceSid string, callSid string, announceURL *url.URL) (*Participant, error) {
+	if announceURL == nil {
+		return nil, errors.New("twilio: Announce requires a URL")
+	}
+	data := url.Values{}
+	data.Set("AnnounceUrl", announceURL.String())
+	return p.Update(ctx, conferenceSid, callSid, data)
+}
+
+// Coach makes the Participant a coach for the Participant with the sid
+// coachedCallSid, so only that Participant can hear them. Pass an empty
+// coachedCallSid to stop coaching.
+func (p *ParticipantService) Coach(ctx context.Context, conferenceSid string, callSid string, coachedCallSid string) (*Participant, error) {
+	data := url.Values{}
+	if coachedCallSid == "" {
+		data.Set("Coaching", "false")
+	} else {
+		data.Set("Coaching", "true")
+		data.Set("CallSidToCoach", coachedCallSid)
+	}
+	return p.Update(ctx, conferenceSid, callSid, data)
+}
+
+// Delete removes (kicks) the Participant from the Conference. If the
+// Participant has already left, or does not exist, Delete returns nil. If
+// another error or a timeout occurs, the error is returned.
+func (p *ParticipantService) Delete(ctx context.Context, conferenceSid string, callSid string) error {
+	return p.client.DeleteResource(ctx, participantPathPart(conferenceSid), callSid)
+}
+
+// GetPage returns a single Page of Participants in the Conference, filtered
+// by data. To retrieve multiple pages, use GetPageIterator.
+func (p *ParticipantService) GetPage(ctx context.Context, conferenceSid string, data url.Values) (*ParticipantPage, error) {
+	return p.GetPageIterator(conferenceSid, data).Next(ctx)
+}
+
+type ParticipantPageIterator struct {
+	p *PageIterator
+}
+
+// GetPageIterator returns a ParticipantPageIterator with the given page
+// filters. Call iterator.Next() to get the first page of resources (and again
+// to retrieve subsequent pages).
+func (p *ParticipantService) GetPageIterator(conferenceSid string, data url.Values) *ParticipantPageIterator {
+	iter := NewPageIterator(p.client, data, participantPathPart(conferenceSid))
+	return &ParticipantPageIterator{
+		p: iter,
+	}
+}
+
+// Next returns the next page of resources. If there are no more resources,
+// NoMoreResults is returned.
+func (p *ParticipantPageIterator) Next(ctx context.Context) (*ParticipantPage, error) {
+	pp := new(ParticipantPage)
+	err := p.p.Next(ctx, pp)
+	if err != nil {
+		return nil, err
+	}
+	p.p.SetNextPageURI(pp.NextPageURI)
+	return pp, nil
 }
@@ -0,0 +1,85 @@
+package twilio
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+const participantCallSid = "CA386025c9bf5d6052a1d1ea42b4d16662"
+
+func TestGetParticipant(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(participantInstance)
+	defer server.Close()
+	p, err := client.Conferences.Participants.Get(context.Background(), conferenceInstanceSid, participantCallSid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.CallSid != participantCallSid {
+		t.Errorf("participant: got CallSid %q, want %q", p.CallSid, participantCallSid)
+	}
+	if !p.Muted || p.Status != Status("connected") || p.Label != "customer" {
+		t.Errorf("bad participant: %#v", p)
+	}
+	want := "/2010-04-01/Accounts/AC123/Conferences/" + conferenceInstanceSid + "/Participants/" + participantCallSid + ".json"
+	if server.URLs[0].String() != want {
+		t.Errorf("request URL:\ngot  %q\nwant %q", server.URLs[0], want)
+	}
+}
+
+func TestGetParticipantPage(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(participantPage)
+	defer server.Close()
+	page, err := client.Conferences.Participants.GetPage(context.Background(), conferenceInstanceSid, url.Values{"Muted": []string{"true"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Participants) != 1 {
+		t.Fatalf("expected 1 participant, got %d", len(page.Participants))
+	}
+	want := "/2010-04-01/Accounts/AC123/Conferences/" + conferenceInstanceSid + "/Participants.json?Muted=true"
+	if server.URLs[0].String() != want {
+		t.Errorf("request URL:\ngot  %q\nwant %q", server.URLs[0], want)
+	}
+}
+
+func TestUpdateParticipant(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(participantInstance)
+	defer server.Close()
+	holdURL, _ := url.Parse("https://example.com/hold.xml")
+	if _, err := client.Conferences.Participants.Hold(context.Background(), conferenceInstanceSid, participantCallSid, true, holdURL); err != nil {
+		t.Fatal(err)
+	}
+	want := "/2010-04-01/Accounts/AC123/Conferences/" + conferenceInstanceSid + "/Participants/" + participantCallSid + ".json"
+	if server.URLs[0].String() != want {
+		t.Errorf("request URL:\ngot  %q\nwant %q", server.URLs[0], want)
+	}
+}
+
+func TestAnnounceParticipant(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(participantInstance)
+	defer server.Close()
+	announceURL, _ := url.Parse("https://example.com/announce.xml")
+	if _, err := client.Conferences.Participants.Announce(context.Background(), conferenceInstanceSid, participantCallSid, announceURL); err != nil {
+		t.Fatal(err)
+	}
+	if got := server.Forms[0].Get("AnnounceUrl"); got != announceURL.String() {
+		t.Errorf("AnnounceUrl: got %q, want %q", got, announceURL)
+	}
+}
+
+func TestAnnounceParticipantNilURL(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(participantInstance)
+	defer server.Close()
+	if _, err := client.Conferences.Participants.Announce(context.Background(), conferenceInstanceSid, participantCallSid, nil); err == nil {
+		t.Fatal("expected an error for a nil URL")
+	}
+	if len(server.URLs) != 0 {
+		t.Errorf("expected no requests, got %d", len(server.URLs))
+	}
+}
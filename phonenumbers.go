@@ -82,6 +82,74 @@ func (ipn *IncomingNumberService) BuyNumber(phoneNumber string) (*IncomingPhoneN
 	return ipn.NumberPurchasingService.Create(context.Background(), data)
 }
 
+// IncomingNumberParams are the parameters for buying a phone number. For more
+// information, see
+// https://www.twilio.com/docs/phone-numbers/api/incomingphonenumber-resource#create-an-incomingphonenumber-resource.
+type IncomingNumberParams struct {
+	// Exactly one of PhoneNumber, an E.164 phone number, and AreaCode must be
+	// set.
+	PhoneNumber  string
+	AreaCode     string
+	FriendlyName string
+	// At most one of VoiceURL and VoiceApplicationSid may be set.
+	VoiceURL            string
+	VoiceMethod         string
+	VoiceFallbackURL    string
+	VoiceApplicationSid string
+	// At most one of SmsURL and SmsApplicationSid may be set.
+	SmsURL               string
+	SmsMethod            string
+	SmsFallbackURL       string
+	SmsApplicationSid    string
+	StatusCallback       string
+	StatusCallbackMethod string
+}
+
+// Validate returns a *ValidationError if p has an invalid phone number, or
+// sets parameters that are mutually exclusive.
+func (p *IncomingNumberParams) Validate() error {
+	if err := exactlyOne([]string{"PhoneNumber", "AreaCode"}, p.PhoneNumber, p.AreaCode); err != nil {
+		return err
+	}
+	if p.PhoneNumber != "" {
+		if err := validatePhoneNumber("PhoneNumber", p.PhoneNumber); err != nil {
+			return err
+		}
+	}
+	if err := atMostOne([]string{"VoiceUrl", "VoiceApplicationSid"}, p.VoiceURL, p.VoiceApplicationSid); err != nil {
+		return err
+	}
+	return atMostOne([]string{"SmsUrl", "SmsApplicationSid"}, p.SmsURL, p.SmsApplicationSid)
+}
+
+// Values returns the form values Twilio expects for p.
+func (p *IncomingNumberParams) Values() url.Values {
+	v := url.Values{}
+	setString(v, "PhoneNumber", p.PhoneNumber)
+	setString(v, "AreaCode", p.AreaCode)
+	setString(v, "FriendlyName", p.FriendlyName)
+	setString(v, "VoiceUrl", p.VoiceURL)
+	setString(v, "VoiceMethod", p.VoiceMethod)
+	setString(v, "VoiceFallbackUrl", p.VoiceFallbackURL)
+	setString(v, "VoiceApplicationSid", p.VoiceApplicationSid)
+	setString(v, "SmsUrl", p.SmsURL)
+	setString(v, "SmsMethod", p.SmsMethod)
+	setString(v, "SmsFallbackUrl", p.SmsFallbackURL)
+	setString(v, "SmsApplicationSid", p.SmsApplicationSid)
+	setString(v, "StatusCallback", p.StatusCallback)
+	setString(v, "StatusCallbackMethod", p.StatusCallbackMethod)
+	return v
+}
+
+// CreateWithParams validates p and buys a phone number. If p is invalid, it
+// returns a *ValidationError without making a request.
+func (n *NumberPurchasingService) CreateWithParams(ctx context.Context, p *IncomingNumberParams) (*IncomingPhoneNumber, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return n.Create(ctx, p.Values())
+}
+
 // Get retrieves a single IncomingPhoneNumber.
 func (ipn *IncomingNumberService) Get(ctx context.Context, sid string) (*IncomingPhoneNumber, error) {
 	number := new(IncomingPhoneNumber)
@@ -2,13 +2,15 @@ package twilio
 
 import (
 	"context"
+	"errors"
 	"net/url"
 )
 
 const queuePathPart = "Queues"
 
 type QueueService struct {
-	client *Client
+	client  *Client
+	Members *QueueMemberService
 }
 
 type Queue struct {
@@ -42,6 +44,14 @@ func (c *QueueService) Create(ctx context.Context, data url.Values) (*Queue, err
 	return queue, err
 }
 
+// Update the Queue with the given data. Valid parameters are "FriendlyName"
+// and "MaxSize".
+func (c *QueueService) Update(ctx context.Context, sid string, data url.Values) (*Queue, error) {
+	queue := new(Queue)
+	err := c.client.UpdateResource(ctx, queuePathPart, sid, data, queue)
+	return queue, err
+}
+
 // Delete the Queue with the given sid. If the Queue has
 // already been deleted, or does not exist, Delete returns nil. If another
 // error or a timeout occurs, the error is returned.
@@ -79,3 +89,91 @@ func (c *QueuePageIterator) Next(ctx context.Context) (*QueuePage, error) {
 	c.p.SetNextPageURI(qp.NextPageURI)
 	return qp, nil
 }
+
+// A QueueMemberService lets you list the calls waiting in a Queue, and
+// dequeue them.
+type QueueMemberService struct {
+	client *Client
+}
+
+func queueMemberPathPart(queueSid string) string {
+	return queuePathPart + "/" + queueSid + "/Members"
+}
+
+// A QueueMember is a call waiting in a Queue.
+type QueueMember struct {
+	CallSid      string     `json:"call_sid"`
+	QueueSid     string     `json:"queue_sid"`
+	DateEnqueued TwilioTime `json:"date_enqueued"`
+	// Position is the call's position in the Queue, starting at 1.
+	Position uint `json:"position"`
+	// WaitTime is the number of seconds the call has been waiting.
+	WaitTime uint   `json:"wait_time"`
+	URI      string `json:"uri"`
+}
+
+type QueueMemberPage struct {
+	Page
+	Members []*QueueMember `json:"queue_members"`
+}
+
+// Get returns the QueueMember with the given Call sid.
+func (c *QueueMemberService) Get(ctx context.Context, queueSid string, callSid string) (*QueueMember, error) {
+	member := new(QueueMember)
+	err := c.client.GetResource(ctx, queueMemberPathPart(queueSid), callSid, member)
+	return member, err
+}
+
+// Front returns the QueueMember at the front of the Queue.
+func (c *QueueMemberService) Front(ctx context.Context, queueSid string) (*QueueMember, error) {
+	return c.Get(ctx, queueSid, "Front")
+}
+
+// Dequeue removes the call with the given sid from the Queue, and redirects
+// it to the TwiML at u. It returns an error if u is nil.
+func (c *QueueMemberService) Dequeue(ctx context.Context, queueSid string, callSid string, u *url.URL) (*QueueMember, error) {
+	if u == nil {
+		return nil, errors.New("twilio: Dequeue requires a TwiML URL")
+	}
+	data := url.Values{}
+	data.Set("Url", u.String())
+	member := new(QueueMember)
+	err := c.client.UpdateResource(ctx, queueMemberPathPart(queueSid), callSid, data, member)
+	return member, err
+}
+
+// DequeueFront removes the call at the front of the Queue, and redirects it to
+// the TwiML at u.
+func (c *QueueMemberService) DequeueFront(ctx context.Context, queueSid string, u *url.URL) (*QueueMember, error) {
+	return c.Dequeue(ctx, queueSid, "Front", u)
+}
+
+func (c *QueueMemberService) GetPage(ctx context.Context, queueSid string, data url.Values) (*QueueMemberPage, error) {
+	return c.GetPageIterator(queueSid, data).Next(ctx)
+}
+
+type QueueMemberPageIterator struct {
+	p *PageIterator
+}
+
+// GetPageIterator returns a QueueMemberPageIterator with the given page
+// filters. Call iterator.Next() to get the first page of resources (and again
+// to retrieve subsequent pages).
+func (c *QueueMemberService) GetPageIterator(queueSid string, data url.Values) *QueueMemberPageIterator {
+	iter := NewPageIterator(c.client, data, queueMemberPathPart(queueSid))
+	return &QueueMemberPageIterator{
+		p: iter,
+	}
+}
+
+// Next returns the next page of resources. If there are no more resources,
+// NoMoreResults is returned.
+func (c *QueueMemberPageIterator) Next(ctx context.Context) (*QueueMemberPage, error) {
+	qp := new(QueueMemberPage)
+	err := c.p.Next(ctx, qp)
+	if err != nil {
+		return nil, err
+	}
+	c.p.SetNextPageURI(qp.NextPageURI)
+	return qp, nil
+}
@@ -0,0 +1,93 @@
+package twilio
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+const queueSid = "QU5ef8732a3c49700934481addd5ce1659"
+
+func TestQueueMemberFront(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(queueMemberInstance)
+	defer server.Close()
+	member, err := client.Queues.Members.Front(context.Background(), queueSid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if member.Position != 1 || member.WaitTime != 143 || !member.DateEnqueued.Valid {
+		t.Errorf("bad member: %#v", member)
+	}
+	want := "/2010-04-01/Accounts/AC123/Queues/" + queueSid + "/Members/Front.json"
+	if server.URLs[0].String() != want {
+		t.Errorf("request URL:\ngot  %q\nwant %q", server.URLs[0], want)
+	}
+}
+
+func TestQueueMemberPage(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(queueMemberPage)
+	defer server.Close()
+	page, err := client.Queues.Members.GetPage(context.Background(), queueSid, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Members) != 2 {
+		t.Fatalf("expected 2 members, got %d", len(page.Members))
+	}
+	if page.Members[1].Position != 2 || page.Members[1].WaitTime != 110 {
+		t.Errorf("bad member: %#v", page.Members[1])
+	}
+}
+
+func TestQueueMemberDequeue(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(queueMemberInstance)
+	defer server.Close()
+	u, _ := url.Parse("https://example.com/agent.xml")
+	callSid := "CA5ef8732a3c49700934481addd5ce1659"
+	if _, err := client.Queues.Members.Dequeue(context.Background(), queueSid, callSid, u); err != nil {
+		t.Fatal(err)
+	}
+	want := "/2010-04-01/Accounts/AC123/Queues/" + queueSid + "/Members/" + callSid + ".json"
+	if server.URLs[0].String() != want {
+		t.Errorf("request URL:\ngot  %q\nwant %q", server.URLs[0], want)
+	}
+	if server.Forms[0].Get("Url") != u.String() {
+		t.Errorf("expected Url=%s, got %v", u, server.Forms[0])
+	}
+}
+
+func TestQueueMemberDequeueNilURL(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(queueMemberInstance)
+	defer server.Close()
+	if _, err := client.Queues.Members.DequeueFront(context.Background(), queueSid, nil); err == nil {
+		t.Fatal("expected an error for a nil URL")
+	}
+	if len(server.URLs) != 0 {
+		t.Errorf("expected no requests, got %d", len(server.URLs))
+	}
+}
+
+func TestQueueUpdate(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(queueInstance)
+	defer server.Close()
+	data := url.Values{"MaxSize": {"50"}}
+	queue, err := client.Queues.Update(context.Background(), queueSid, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queue.MaxSize != 50 || queue.FriendlyName != "support" {
+		t.Errorf("bad queue: %#v", queue)
+	}
+	want := "/2010-04-01/Accounts/AC123/Queues/" + queueSid + ".json"
+	if server.URLs[0].String() != want {
+		t.Errorf("request URL:\ngot  %q\nwant %q", server.URLs[0], want)
+	}
+	if server.Forms[0].Get("MaxSize") != "50" {
+		t.Errorf("expected MaxSize=50, got %v", server.Forms[0])
+	}
+}
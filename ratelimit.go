@@ -0,0 +1,172 @@
+package twilio
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ErrRateLimitWait is returned by MakeRequest, and methods like
+// Messages.Create, when the Client's Limiter would have to wait past the
+// Context's deadline before sending the request.
+var ErrRateLimitWait = errors.New("twilio: rate limit wait would exceed context deadline")
+
+// A Limiter controls how fast a Client sends requests. Set one on a Client
+// with SetLimiter.
+type Limiter interface {
+	// Wait blocks until r may be sent. It returns an error without sending
+	// the request if ctx is done first, and should return ErrRateLimitWait
+	// right away if ctx's deadline is too soon for r to be sent in time.
+	Wait(ctx context.Context, r *Request) error
+}
+
+// A Rate configures a token bucket. Limit is the average number of requests
+// per second, and Burst is the number of requests that may be sent at once.
+// A Rate with a zero Limit is unlimited.
+type Rate struct {
+	Limit float64
+	// Burst is the bucket size. Values less than 1 are treated as 1.
+	Burst int
+}
+
+// Rates for the senders Twilio limits by default. See
+// https://support.twilio.com/hc/en-us/articles/115002943027.
+var (
+	LongCodeRate  = Rate{Limit: 1, Burst: 1}
+	TollFreeRate  = Rate{Limit: 3, Burst: 3}
+	ShortCodeRate = Rate{Limit: 100, Burst: 100}
+)
+
+// DefaultSenderRate returns the default Twilio rate for sending messages from
+// the given number: ShortCodeRate for short codes, TollFreeRate for US and
+// Canadian toll free numbers and LongCodeRate for everything else.
+func DefaultSenderRate(from string) Rate {
+	if i := strings.IndexByte(from, ':'); i >= 0 {
+		// A channel address, e.g. "whatsapp:+14105551234".
+		from = from[i+1:]
+	}
+	if len(from) >= 5 && len(from) <= 6 && strings.Trim(from, "0123456789") == "" {
+		return ShortCodeRate
+	}
+	for _, prefix := range []string{"+1800", "+1833", "+1844", "+1855", "+1866", "+1877", "+1888"} {
+		if strings.HasPrefix(from, prefix) {
+			return TollFreeRate
+		}
+	}
+	return LongCodeRate
+}
+
+// A RateLimiter is a Limiter with a token bucket for each Account, each API
+// host (api.twilio.com, wireless.twilio.com, etc) and each number that
+// Messages are sent from. A request waits until there is a token in every
+// bucket that applies to it. Several Clients may share a RateLimiter.
+//
+// A RateLimiter must not be copied after first use.
+type RateLimiter struct {
+	// Account limits the requests made for each Account.
+	Account Rate
+	// Host limits the requests made to each API host.
+	Host Rate
+	// Sender returns the rate for creating Messages from the given "From"
+	// number. If Sender is nil, DefaultSenderRate is used. Messages sent
+	// with a MessagingServiceSid and no From are not limited by sender.
+	Sender func(from string) Rate
+
+	mu      sync.Mutex
+	buckets map[string]*rate.Limiter
+}
+
+// NewRateLimiter returns a RateLimiter that limits each Account to
+// accountRate, and each sender to DefaultSenderRate.
+func NewRateLimiter(accountRate Rate) *RateLimiter {
+	return &RateLimiter{Account: accountRate}
+}
+
+func (l *RateLimiter) bucket(key string, r Rate) *rate.Limiter {
+	if r.Limit <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.buckets == nil {
+		l.buckets = make(map[string]*rate.Limiter)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		burst := r.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		b = rate.NewLimiter(rate.Limit(r.Limit), burst)
+		l.buckets[key] = b
+	}
+	return b
+}
+
+// Wait implements Limiter.
+func (l *RateLimiter) Wait(ctx context.Context, r *Request) error {
+	buckets := []*rate.Limiter{
+		l.bucket("account:"+r.AccountSid, l.Account),
+		l.bucket("host:"+r.HTTPRequest.URL.Host, l.Host),
+	}
+	if from := r.Data.Get("From"); from != "" && r.Method == "POST" && strings.HasSuffix(r.Path, "/"+messagesPathPart+".json") {
+		senderRate := DefaultSenderRate
+		if l.Sender != nil {
+			senderRate = l.Sender
+		}
+		buckets = append(buckets, l.bucket("sender:"+from, senderRate(from)))
+	}
+
+	now := time.Now()
+	var reservations []*rate.Reservation
+	cancel := func() {
+		for _, res := range reservations {
+			res.Cancel()
+		}
+	}
+	var delay time.Duration
+	for _, b := range buckets {
+		if b == nil {
+			continue
+		}
+		res := b.ReserveN(now, 1)
+		reservations = append(reservations, res)
+		if d := res.DelayFrom(now); d > delay {
+			delay = d
+		}
+	}
+	if delay == 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+		cancel()
+		return ErrRateLimitWait
+	}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		cancel()
+		return ctx.Err()
+	}
+}
+
+// SetLimiter configures the Client, and every product Client created by
+// NewClient (Monitor, Pricing, Wireless, etc), to wait for l before each
+// request, including each retry. Pass nil to send requests without waiting,
+// which is the default.
+//
+// SetLimiter is not thread safe; call it before making any requests.
+func (c *Client) SetLimiter(l Limiter) {
+	c.Limiter = l
+	c.forgetAccounts()
+	for _, sub := range c.subClients() {
+		sub.SetLimiter(l)
+	}
+}
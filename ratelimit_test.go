@@ -0,0 +1,132 @@
+package twilio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRateLimitServer() (*httptest.Server, *int32) {
+	var count int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(201)
+		w.Write(sendMessageResponse)
+	}))
+	return s, &count
+}
+
+func TestRateLimiterSender(t *testing.T) {
+	t.Parallel()
+	s, _ := newRateLimitServer()
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	client.SetLimiter(&RateLimiter{
+		Sender: func(from string) Rate {
+			if from == "+14105551234" {
+				return Rate{Limit: 20, Burst: 1}
+			}
+			return Rate{}
+		},
+	})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Messages.SendMessage("+14105551234", to, "hi", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected 3 messages at 20 per second to take at least 100ms, took %v", elapsed)
+	}
+
+	// Other senders, and other resources, aren't limited.
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Messages.SendMessage("+14105556789", to, "hi", nil); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.Messages.Get(context.Background(), "SM123"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 90*time.Millisecond {
+		t.Errorf("expected unlimited requests to be fast, took %v", elapsed)
+	}
+}
+
+func TestRateLimiterFailFast(t *testing.T) {
+	t.Parallel()
+	s, count := newRateLimitServer()
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	client.SetLimiter(NewRateLimiter(Rate{Limit: 1, Burst: 1}))
+	if _, err := client.Messages.SendMessage(from, to, "hi", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.Calls.Get(ctx, "CA123")
+	if err != ErrRateLimitWait {
+		t.Errorf("expected ErrRateLimitWait, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("expected request to fail without waiting, took %v", elapsed)
+	}
+	if n := atomic.LoadInt32(count); n != 1 {
+		t.Errorf("expected 1 request to the server, got %d", n)
+	}
+
+	// The product clients share the Account bucket.
+	client.Wireless.Base = s.URL
+	if _, err := client.Wireless.Sims.Get(ctx, "DE123"); err != ErrRateLimitWait {
+		t.Errorf("expected ErrRateLimitWait, got %v", err)
+	}
+}
+
+func TestRateLimiterCanceled(t *testing.T) {
+	t.Parallel()
+	s, _ := newRateLimitServer()
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	client.SetLimiter(&RateLimiter{Host: Rate{Limit: 1}})
+	if _, err := client.Messages.Get(context.Background(), "SM123"); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if _, err := client.Messages.Get(ctx, "SM123"); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+var senderRateTests = []struct {
+	from string
+	want Rate
+}{
+	{"+14105551234", LongCodeRate},
+	{"+18005551234", TollFreeRate},
+	{"+18885551234", TollFreeRate},
+	{"894546", ShortCodeRate},
+	{"12345", ShortCodeRate},
+	{"whatsapp:+14105551234", LongCodeRate},
+	{"+447700900123", LongCodeRate},
+}
+
+func TestDefaultSenderRate(t *testing.T) {
+	t.Parallel()
+	for _, tt := range senderRateTests {
+		if got := DefaultSenderRate(tt.from); got != tt.want {
+			t.Errorf("DefaultSenderRate(%q): got %v, want %v", tt.from, got, tt.want)
+		}
+	}
+}
@@ -18,7 +18,9 @@ type Server struct {
 	URL string
 	// URLs of incoming requests, in order
 	URLs []*url.URL
-	mu   sync.Mutex
+	// Forms holds the parsed POST body of each request, in order
+	Forms []url.Values
+	mu    sync.Mutex
 }
 
 func (s *Server) Close() {
@@ -36,8 +38,10 @@ func (s *Server) Start() {
 func newServer(response []byte, code int) *Server {
 	serv := &Server{URLs: make([]*url.URL, 0)}
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
 		serv.mu.Lock()
 		serv.URLs = append(serv.URLs, r.URL)
+		serv.Forms = append(serv.Forms, r.PostForm)
 		serv.mu.Unlock()
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(code)
@@ -67,6 +71,7 @@ func getServer(response []byte) (*Client, *Server) {
 	client.TaskRouter.Base = s.URL
 	client.Insights.Base = s.URL
 	client.SuperSim.Base = s.URL
+	client.MessagingServices.Base = s.URL
 	return client, s
 }
 
@@ -83,6 +88,7 @@ func getServerCode(response []byte, code int) (*Client, *Server) {
 	client.Video.Base = s.URL
 	client.TaskRouter.Base = s.URL
 	client.SuperSim.Base = s.URL
+	client.MessagingServices.Base = s.URL
 	return client, s
 }
 
@@ -3823,3 +3829,328 @@ var napNetworkPageResponse = []byte(`
 
 const from = "+19253920364"
 const to = "+19253920364"
+
+var participantInstance = []byte(`
+{
+    "account_sid": "AC58f1e8f2b1c6b88ca90a012a4be0c279",
+    "call_sid": "CA386025c9bf5d6052a1d1ea42b4d16662",
+    "call_sid_to_coach": null,
+    "coaching": false,
+    "conference_sid": "CF169b5eebb07ec48e0f9f2ee904b385c5",
+    "date_created": "Fri, 18 Feb 2011 21:07:19 +0000",
+    "date_updated": "Fri, 18 Feb 2011 21:07:19 +0000",
+    "end_conference_on_exit": false,
+    "hold": false,
+    "label": "customer",
+    "muted": true,
+    "start_conference_on_enter": true,
+    "status": "connected",
+    "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Conferences/CF169b5eebb07ec48e0f9f2ee904b385c5/Participants/CA386025c9bf5d6052a1d1ea42b4d16662.json"
+}
+`)
+
+var participantPage = []byte(`
+{
+    "end": 0,
+    "first_page_uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Conferences/CF169b5eebb07ec48e0f9f2ee904b385c5/Participants.json?PageSize=50&Page=0",
+    "next_page_uri": null,
+    "page": 0,
+    "page_size": 50,
+    "participants": [
+        {
+            "account_sid": "AC58f1e8f2b1c6b88ca90a012a4be0c279",
+            "call_sid": "CA386025c9bf5d6052a1d1ea42b4d16662",
+            "call_sid_to_coach": null,
+            "coaching": false,
+            "conference_sid": "CF169b5eebb07ec48e0f9f2ee904b385c5",
+            "date_created": "Fri, 18 Feb 2011 21:07:19 +0000",
+            "date_updated": "Fri, 18 Feb 2011 21:07:19 +0000",
+            "end_conference_on_exit": false,
+            "hold": false,
+            "label": "customer",
+            "muted": true,
+            "start_conference_on_enter": true,
+            "status": "connected",
+            "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Conferences/CF169b5eebb07ec48e0f9f2ee904b385c5/Participants/CA386025c9bf5d6052a1d1ea42b4d16662.json"
+        }
+    ],
+    "previous_page_uri": null,
+    "start": 0,
+    "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Conferences/CF169b5eebb07ec48e0f9f2ee904b385c5/Participants.json?PageSize=50&Page=0"
+}
+`)
+
+var queueInstance = []byte(`
+{
+    "account_sid": "AC58f1e8f2b1c6b88ca90a012a4be0c279",
+    "average_wait_time": 0,
+    "current_size": 0,
+    "date_created": "Mon, 17 Oct 2022 18:50:11 +0000",
+    "date_updated": "Mon, 17 Oct 2022 19:02:44 +0000",
+    "friendly_name": "support",
+    "max_size": 50,
+    "sid": "QU5ef8732a3c49700934481addd5ce1659",
+    "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Queues/QU5ef8732a3c49700934481addd5ce1659.json"
+}
+`)
+
+var queueMemberInstance = []byte(`
+{
+    "call_sid": "CA5ef8732a3c49700934481addd5ce1659",
+    "date_enqueued": "Mon, 17 Oct 2022 18:55:29 +0000",
+    "position": 1,
+    "queue_sid": "QU5ef8732a3c49700934481addd5ce1659",
+    "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Queues/QU5ef8732a3c49700934481addd5ce1659/Members/CA5ef8732a3c49700934481addd5ce1659.json",
+    "wait_time": 143
+}
+`)
+
+var queueMemberPage = []byte(`
+{
+    "end": 0,
+    "first_page_uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Queues/QU5ef8732a3c49700934481addd5ce1659/Members.json?PageSize=50&Page=0",
+    "next_page_uri": null,
+    "page": 0,
+    "page_size": 50,
+    "previous_page_uri": null,
+    "queue_members": [
+        {
+            "call_sid": "CA5ef8732a3c49700934481addd5ce1659",
+            "date_enqueued": "Mon, 17 Oct 2022 18:55:29 +0000",
+            "position": 1,
+            "queue_sid": "QU5ef8732a3c49700934481addd5ce1659",
+            "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Queues/QU5ef8732a3c49700934481addd5ce1659/Members/CA5ef8732a3c49700934481addd5ce1659.json",
+            "wait_time": 143
+        },
+        {
+            "call_sid": "CA6ef8732a3c49700934481addd5ce1660",
+            "date_enqueued": "Mon, 17 Oct 2022 18:56:02 +0000",
+            "position": 2,
+            "queue_sid": "QU5ef8732a3c49700934481addd5ce1659",
+            "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Queues/QU5ef8732a3c49700934481addd5ce1659/Members/CA6ef8732a3c49700934481addd5ce1660.json",
+            "wait_time": 110
+        }
+    ],
+    "start": 0,
+    "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Queues/QU5ef8732a3c49700934481addd5ce1659/Members.json?PageSize=50&Page=0"
+}
+`)
+
+var callFeedbackInstance = []byte(`
+{
+    "account_sid": "AC58f1e8f2b1c6b88ca90a012a4be0c279",
+    "date_created": "Thu, 20 Aug 2015 21:45:46 +0000",
+    "date_updated": "Thu, 20 Aug 2015 21:45:46 +0000",
+    "issues": [
+        "imperfect-audio",
+        "post-dial-delay"
+    ],
+    "quality_score": 2,
+    "sid": "CF1e5bdabdb1ca68c6c6b5d1cda0e7fb68"
+}
+`)
+
+var callNotificationPage = []byte(`
+{
+    "end": 0,
+    "first_page_uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Calls/CA47b862ce3b99a6d79939320a9aa54a02/Notifications.json?PageSize=50&Page=0",
+    "next_page_uri": null,
+    "notifications": [
+        {
+            "account_sid": "AC58f1e8f2b1c6b88ca90a012a4be0c279",
+            "api_version": "2008-08-01",
+            "call_sid": "CA47b862ce3b99a6d79939320a9aa54a02",
+            "date_created": "Tue, 18 Aug 2015 08:46:56 +0000",
+            "date_updated": "Tue, 18 Aug 2015 08:46:57 +0000",
+            "error_code": "11200",
+            "log": "0",
+            "message_date": "Tue, 18 Aug 2015 08:46:56 +0000",
+            "message_text": "msg=Got+HTTP+500+response+to+https%3A%2F%2Fexample.com%2Fvoice",
+            "more_info": "https://www.twilio.com/docs/errors/11200",
+            "request_method": "POST",
+            "request_url": "https://example.com/voice",
+            "sid": "NO5a7a84730f529f0a76b3e30c01315d1a",
+            "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Calls/CA47b862ce3b99a6d79939320a9aa54a02/Notifications/NO5a7a84730f529f0a76b3e30c01315d1a.json"
+        }
+    ],
+    "page": 0,
+    "page_size": 50,
+    "previous_page_uri": null,
+    "start": 0,
+    "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Calls/CA47b862ce3b99a6d79939320a9aa54a02/Notifications.json?PageSize=50&Page=0"
+}
+`)
+
+var callRequestEventPage = []byte(`
+{
+    "end": 0,
+    "events": [
+        {
+            "request": {
+                "method": "POST",
+                "url": "https://example.com/voice",
+                "parameters": {
+                    "CallSid": "CA47b862ce3b99a6d79939320a9aa54a02",
+                    "CallStatus": "ringing",
+                    "Direction": "inbound"
+                }
+            },
+            "response": {
+                "content_type": "text/xml",
+                "date_created": "Tue, 18 Aug 2015 08:46:56 +0000",
+                "request_duration": 121,
+                "response_body": "<Response><Say>Hello</Say></Response>",
+                "response_code": 200
+            }
+        }
+    ],
+    "first_page_uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Calls/CA47b862ce3b99a6d79939320a9aa54a02/Events.json?PageSize=50&Page=0",
+    "next_page_uri": null,
+    "page": 0,
+    "page_size": 50,
+    "previous_page_uri": null,
+    "start": 0,
+    "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Calls/CA47b862ce3b99a6d79939320a9aa54a02/Events.json?PageSize=50&Page=0"
+}
+`)
+
+var usageRecordPage = []byte(`
+{
+    "end": 1,
+    "first_page_uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Usage/Records/Monthly.json?Category=sms&PageSize=50&Page=0",
+    "next_page_uri": null,
+    "page": 0,
+    "page_size": 50,
+    "previous_page_uri": null,
+    "start": 0,
+    "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Usage/Records/Monthly.json?Category=sms&PageSize=50&Page=0",
+    "usage_records": [
+        {
+            "account_sid": "AC58f1e8f2b1c6b88ca90a012a4be0c279",
+            "api_version": "2010-04-01",
+            "as_of": "2022-10-17T18:55:29+00:00",
+            "category": "sms",
+            "count": "1604",
+            "count_unit": "messages",
+            "description": "SMS Messages",
+            "end_date": "2022-09-30",
+            "price": "12.7120",
+            "price_unit": "usd",
+            "start_date": "2022-09-01",
+            "subresource_uris": {},
+            "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Usage/Records/Monthly.json?Category=sms&StartDate=2022-09-01&EndDate=2022-09-30",
+            "usage": "1604",
+            "usage_unit": "messages"
+        },
+        {
+            "account_sid": "AC58f1e8f2b1c6b88ca90a012a4be0c279",
+            "api_version": "2010-04-01",
+            "as_of": "2022-10-17T18:55:29+00:00",
+            "category": "sms",
+            "count": 0,
+            "count_unit": "messages",
+            "description": "SMS Messages",
+            "end_date": "2022-10-17",
+            "price": 0,
+            "price_unit": "usd",
+            "start_date": "2022-10-01",
+            "subresource_uris": {},
+            "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Usage/Records/Monthly.json?Category=sms&StartDate=2022-10-01&EndDate=2022-10-17",
+            "usage": 0,
+            "usage_unit": "messages"
+        }
+    ]
+}
+`)
+
+var usageTriggerInstance = []byte(`
+{
+    "account_sid": "AC58f1e8f2b1c6b88ca90a012a4be0c279",
+    "api_version": "2010-04-01",
+    "callback_method": "POST",
+    "callback_url": "https://example.com/usage",
+    "current_value": "12.7120",
+    "date_created": "Mon, 17 Oct 2022 18:55:29 +0000",
+    "date_fired": null,
+    "date_updated": "Mon, 17 Oct 2022 18:55:29 +0000",
+    "friendly_name": "Monthly SMS spend",
+    "recurring": "monthly",
+    "sid": "UT33c6aeeba34e48f38d6899ea5b765ad4",
+    "trigger_by": "price",
+    "trigger_value": "100",
+    "uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Usage/Triggers/UT33c6aeeba34e48f38d6899ea5b765ad4.json",
+    "usage_category": "sms",
+    "usage_record_uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Usage/Records/ThisMonth.json?Category=sms"
+}
+`)
+
+var messagingServiceResponse = []byte(`
+{
+    "account_sid": "AC58f1e8f2b1c6b88ca90a012a4be0c279",
+    "area_code_geomatch": true,
+    "date_created": "2015-07-30T20:12:31Z",
+    "date_updated": "2015-07-30T20:12:33Z",
+    "fallback_method": "POST",
+    "fallback_to_long_code": true,
+    "fallback_url": "https://www.example.com/fallback",
+    "friendly_name": "Notifications",
+    "inbound_method": "POST",
+    "inbound_request_url": "https://www.example.com/inbound",
+    "links": {
+        "alpha_senders": "https://messaging.twilio.com/v1/Services/MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa/AlphaSenders",
+        "phone_numbers": "https://messaging.twilio.com/v1/Services/MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa/PhoneNumbers",
+        "short_codes": "https://messaging.twilio.com/v1/Services/MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa/ShortCodes"
+    },
+    "mms_converter": true,
+    "scan_message_content": "inherit",
+    "sid": "MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+    "smart_encoding": false,
+    "status_callback": "https://www.example.com/status",
+    "sticky_sender": true,
+    "synchronous_validation": true,
+    "url": "https://messaging.twilio.com/v1/Services/MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+    "usecase": "notifications",
+    "use_inbound_webhook_on_number": false,
+    "validity_period": 600
+}
+`)
+
+var servicePhoneNumberPageResponse = []byte(`
+{
+    "meta": {
+        "first_page_url": "https://messaging.twilio.com/v1/Services/MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa/PhoneNumbers?PageSize=50&Page=0",
+        "key": "phone_numbers",
+        "next_page_url": null,
+        "page": 0,
+        "page_size": 50,
+        "previous_page_url": null,
+        "url": "https://messaging.twilio.com/v1/Services/MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa/PhoneNumbers?PageSize=50&Page=0"
+    },
+    "phone_numbers": [
+        {
+            "account_sid": "AC58f1e8f2b1c6b88ca90a012a4be0c279",
+            "capabilities": ["SMS", "MMS"],
+            "country_code": "US",
+            "date_created": "2015-07-30T20:12:31Z",
+            "date_updated": "2015-07-30T20:12:33Z",
+            "phone_number": "+19253920364",
+            "service_sid": "MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+            "sid": "PNaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+            "url": "https://messaging.twilio.com/v1/Services/MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa/PhoneNumbers/PNaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+        }
+    ]
+}
+`)
+
+var alphaSenderResponse = []byte(`
+{
+    "account_sid": "AC58f1e8f2b1c6b88ca90a012a4be0c279",
+    "alpha_sender": "Twilio",
+    "capabilities": ["SMS"],
+    "date_created": "2015-07-30T20:12:31Z",
+    "date_updated": "2015-07-30T20:12:33Z",
+    "service_sid": "MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+    "sid": "AIaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+    "url": "https://messaging.twilio.com/v1/Services/MGaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa/AlphaSenders/AIaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+}
+`)
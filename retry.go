@@ -0,0 +1,151 @@
+package twilio
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// A RetryPolicy controls how MakeRequest retries requests that fail with a
+// transient error. Set it on a Client with SetRetryPolicy.
+//
+// Only requests that are safe to repeat are retried. GET and DELETE requests
+// are retried after connection errors, 429 Too Many Requests responses, and
+// 5xx responses. POST requests are retried only if the connection failed
+// before the request was written to the network, since otherwise Twilio may
+// have already sent the message or placed the call. This is true even for POST
+// requests made with a Context from WithIdempotencyKey, since Twilio may not
+// use the key to ignore repeated requests.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values less than 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the upper bound of the delay before the first retry. The
+	// bound doubles after each attempt, and the actual delay is chosen at
+	// random between zero and the bound.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts, including delays requested
+	// by the server with a Retry-After header.
+	MaxDelay time.Duration
+	// OnRetry, if non-nil, is called before sleeping ahead of each retry.
+	OnRetry func(RetryEvent)
+}
+
+// A RetryEvent describes a failed attempt that is about to be retried.
+type RetryEvent struct {
+	Method string
+	// Path is the resource path, e.g. "/2010-04-01/Accounts/AC123/Calls.json".
+	Path string
+	// Attempt is the number of the attempt that failed, starting at 1.
+	Attempt int
+	// StatusCode is the HTTP status of the failed attempt, or 0 if no
+	// response was received.
+	StatusCode int
+	// Err is the error returned by the failed attempt.
+	Err error
+	// Delay is how long the client will wait before the next attempt.
+	Delay time.Duration
+	// IdempotencyKey is the key sent with every attempt, if any.
+	IdempotencyKey string
+}
+
+// DefaultRetryPolicy makes up to three attempts, starting with a delay of up
+// to half a second.
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// SetRetryPolicy configures the Client, and every product Client created by
+// NewClient (Monitor, Pricing, Wireless, etc), to retry failed requests
+// according to p. Pass nil to disable retries, which is the default.
+//
+// SetRetryPolicy is not thread safe; call it before making any requests.
+func (c *Client) SetRetryPolicy(p *RetryPolicy) {
+	c.RetryPolicy = p
+	c.forgetAccounts()
+	for _, sub := range c.subClients() {
+		sub.SetRetryPolicy(p)
+	}
+}
+
+// retryable reports whether an attempt that returned resp and err may be
+// repeated. sent reports whether the request was written to the connection.
+func retryable(ctx context.Context, method string, resp *http.Response, sent bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if resp == nil {
+		return method != "POST" || !sent
+	}
+	if method != "GET" && method != "DELETE" {
+		return false
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// delay returns how long to wait before the attempt following the given
+// (1-indexed) attempt.
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxDelay > 0 && d > p.MaxDelay {
+				return p.MaxDelay
+			}
+			return d
+		}
+	}
+	bound := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || bound < p.MaxDelay); i++ {
+		bound *= 2
+	}
+	if p.MaxDelay > 0 && bound > p.MaxDelay {
+		bound = p.MaxDelay
+	}
+	if bound <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(bound)))
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which may be
+// either a number of seconds or an HTTP date.
+func parseRetryAfter(val string) (time.Duration, bool) {
+	if val == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(val); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(val)
+	if err != nil {
+		return 0, false
+	}
+	d := time.Until(t)
+	if d < 0 {
+		d = 0
+	}
+	return d, true
+}
+
+// sleep waits for d, returning early with the context's error if ctx is
+// canceled or its deadline would pass before d elapses.
+func sleep(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return context.DeadlineExceeded
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,159 @@
+package twilio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryServer(failures int32, code int) (*httptest.Server, *int32) {
+	var count int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if atomic.AddInt32(&count, 1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(code)
+			w.Write([]byte(`{"code": 20429, "message": "Too many requests", "status": 429}`))
+			return
+		}
+		w.WriteHeader(200)
+		w.Write(makeCallResponse)
+	}))
+	return s, &count
+}
+
+func TestRetryGet(t *testing.T) {
+	t.Parallel()
+	s, count := newRetryServer(2, 503)
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	var events []RetryEvent
+	client.SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 3,
+		OnRetry:     func(ev RetryEvent) { events = append(events, ev) },
+	})
+	call, err := client.Calls.Get(context.Background(), "CA47b862ce3b99a6d79939320a9aa54a02")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Sid != "CA47b862ce3b99a6d79939320a9aa54a02" {
+		t.Errorf("wrong sid: %s", call.Sid)
+	}
+	if n := atomic.LoadInt32(count); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 retry events, got %d", len(events))
+	}
+	if events[0].Attempt != 1 || events[0].StatusCode != 503 || events[0].Method != "GET" {
+		t.Errorf("bad retry event: %#v", events[0])
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	t.Parallel()
+	s, count := newRetryServer(5, 429)
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	client.SetRetryPolicy(&RetryPolicy{MaxAttempts: 2})
+	_, err := client.Calls.Get(context.Background(), "CA47b862ce3b99a6d79939320a9aa54a02")
+	if err == nil {
+		t.Fatal("expected non-nil error, got nil")
+	}
+	if n := atomic.LoadInt32(count); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
+
+func TestRetryPostNotRetriedAfterSend(t *testing.T) {
+	t.Parallel()
+	s, count := newRetryServer(1, 500)
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	client.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3})
+	_, err := client.Calls.Create(context.Background(), url.Values{"To": []string{"+14105551234"}})
+	if err == nil {
+		t.Fatal("expected non-nil error, got nil")
+	}
+	if n := atomic.LoadInt32(count); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
+
+func TestRetryPostConnectionRefused(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(http.NotFoundHandler())
+	base := s.URL
+	s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = base
+	attempts := 0
+	client.SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 3,
+		OnRetry:     func(RetryEvent) { attempts++ },
+	})
+	_, err := client.Calls.Create(context.Background(), url.Values{"To": []string{"+14105551234"}})
+	if err == nil {
+		t.Fatal("expected non-nil error, got nil")
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 retries, got %d", attempts)
+	}
+}
+
+func TestSetRetryPolicyPropagates(t *testing.T) {
+	t.Parallel()
+	client := NewClient("AC123", "456", nil)
+	client.SetRetryPolicy(DefaultRetryPolicy)
+	for _, sub := range []*Client{client.Monitor, client.Pricing, client.Wireless, client.TaskRouter, client.SuperSim} {
+		if sub.RetryPolicy != DefaultRetryPolicy {
+			t.Errorf("expected sub client for %s to inherit the retry policy", sub.Base)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	t.Parallel()
+	p := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt := 1; attempt < 10; attempt++ {
+		if d := p.delay(attempt, nil); d < 0 || d > time.Second {
+			t.Errorf("attempt %d: delay %v out of range", attempt, d)
+		}
+	}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	if d := p.delay(1, resp); d != time.Second {
+		t.Errorf("expected Retry-After to be capped at MaxDelay, got %v", d)
+	}
+	resp.Header.Set("Retry-After", "abc")
+	if d := p.delay(1, resp); d > 100*time.Millisecond {
+		t.Errorf("expected invalid Retry-After to be ignored, got %v", d)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-1", 0, false},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q): got (%v, %v), want (%v, %v)", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
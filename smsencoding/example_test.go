@@ -0,0 +1,34 @@
+package smsencoding_test
+
+import (
+	"fmt"
+
+	"github.com/kevinburke/twilio-go/smsencoding"
+)
+
+func ExampleAnalyze() {
+	a := smsencoding.Analyze("Your order has shipped! It’ll arrive Tuesday.")
+	fmt.Println(a.Encoding, a.Segments, string(a.NonGSM))
+	// Output: UCS-2 1 ’
+}
+
+func ExampleSmartEncode() {
+	body := "“Flash sale” — 20% off everything in store & online… today only, don’t miss it!"
+	before := smsencoding.Analyze(body)
+	after := smsencoding.Analyze(smsencoding.SmartEncode(body))
+	fmt.Println(before.Encoding, before.Segments)
+	fmt.Println(after.Encoding, after.Segments)
+	// Output:
+	// UCS-2 2
+	// GSM-7 1
+}
+
+func ExampleAnalysis_Price() {
+	// The price of one outbound SMS, e.g. the CurrentPrice from
+	// client.Pricing.Messaging.Countries.Get.
+	perSegment := "0.0079"
+	a := smsencoding.Analyze("Reminder: your appointment with Dr. Smith is tomorrow at 3pm. Reply C to confirm or R to reschedule. Call (410) 555-1234 with any questions.")
+	price, _ := a.Price(perSegment)
+	fmt.Println(a.Segments, price)
+	// Output: 1 0.0079
+}
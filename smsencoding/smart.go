@@ -0,0 +1,174 @@
+package smsencoding
+
+import "strings"
+
+// smartEncodings maps Unicode characters to the GSM-7 characters Twilio's
+// Smart Encoding replaces them with. Characters that map to the empty string,
+// like zero width spaces, are removed.
+var smartEncodings = map[rune]string{
+	// Quotation marks
+	'«':      "\"",
+	'»':      "\"",
+	'“':      "\"",
+	'”':      "\"",
+	'ʺ':      "\"",
+	'ˮ':      "\"",
+	'‟':      "\"",
+	'❝':      "\"",
+	'❞':      "\"",
+	'〝':      "\"",
+	'〞':      "\"",
+	'＂':      "\"",
+	'‘':      "'",
+	'’':      "'",
+	'ʻ':      "'",
+	'ˈ':      "'",
+	'ʼ':      "'",
+	'ʽ':      "'",
+	'ʹ':      "'",
+	'‛':      "'",
+	'＇':      "'",
+	'´':      "'",
+	'ˊ':      "'",
+	'`':      "'",
+	'ˋ':      "'",
+	'❛':      "'",
+	'❜':      "'",
+	'\u0313': "'", // combining comma above
+	'\u0314': "'", // combining reversed comma above
+	'︐':      "'",
+	'︑':      "'",
+	'‹':      "'",
+	'›':      "'",
+
+	// Dashes, hyphens and bullets
+	'\u00ad': "-", // soft hyphen
+	'‐':      "-",
+	'‑':      "-",
+	'‒':      "-",
+	'–':      "-",
+	'—':      "-",
+	'―':      "-",
+	'−':      "-",
+	'﹘':      "-",
+	'﹣':      "-",
+	'－':      "-",
+	'•':      "-",
+
+	// Slashes and underscores
+	'÷':      "/",
+	'⁄':      "/",
+	'∕':      "/",
+	'／':      "/",
+	'⧸':      "/",
+	'⧹':      "\\",
+	'⧵':      "\\",
+	'﹨':      "\\",
+	'＼':      "\\",
+	'\u0332': "_", // combining low line
+	'＿':      "_",
+	'﹍':      "_",
+	'﹎':      "_",
+	'﹏':      "_",
+
+	// Other punctuation
+	'…': "...",
+	'ˆ': "^",
+	'‸': "^",
+	'⌃': "^",
+	'˜': "~",
+	'⁓': "~",
+	'∼': "~",
+	'～': "~",
+	'！': "!",
+	'？': "?",
+	'：': ":",
+	'；': ";",
+	'，': ",",
+	'．': ".",
+	'¼': "1/4",
+	'½': "1/2",
+	'¾': "3/4",
+
+	// Spaces
+	'\u00a0': " ", // no-break space
+	'\u2000': " ", // en quad
+	'\u2001': " ", // em quad
+	'\u2002': " ", // en space
+	'\u2003': " ", // em space
+	'\u2004': " ", // three-per-em space
+	'\u2005': " ", // four-per-em space
+	'\u2006': " ", // six-per-em space
+	'\u2007': " ", // figure space
+	'\u2008': " ", // punctuation space
+	'\u2009': " ", // thin space
+	'\u200a': " ", // hair space
+	'\u202f': " ", // narrow no-break space
+	'\u205f': " ", // medium mathematical space
+	'\u3000': " ", // ideographic space
+	'\u2028': " ", // line separator
+	'\u2029': " ", // paragraph separator
+	'\u200b': "",  // zero width space
+	'\u200c': "",  // zero width non-joiner
+	'\u200d': "",  // zero width joiner
+	'\u2060': "",  // word joiner
+	'\ufeff': "",  // zero width no-break space
+
+	// Accented letters that aren't in GSM-7
+	'À': "A",
+	'Á': "A",
+	'Â': "A",
+	'Ã': "A",
+	'È': "E",
+	'Ê': "E",
+	'Ë': "E",
+	'Ì': "I",
+	'Í': "I",
+	'Î': "I",
+	'Ï': "I",
+	'Ò': "O",
+	'Ó': "O",
+	'Ô': "O",
+	'Õ': "O",
+	'Ù': "U",
+	'Ú': "U",
+	'Û': "U",
+	'Ý': "Y",
+	'á': "a",
+	'â': "a",
+	'ã': "a",
+	'ç': "c",
+	'ê': "e",
+	'ë': "e",
+	'í': "i",
+	'î': "i",
+	'ï': "i",
+	'ó': "o",
+	'ô': "o",
+	'õ': "o",
+	'ú': "u",
+	'û': "u",
+	'ý': "y",
+	'ÿ': "y",
+}
+
+// SmartEncode returns body with the substitutions Twilio makes when Smart
+// Encoding is enabled on a Messaging Service, like replacing curly quotes with
+// straight ones and em dashes with hyphens. Characters without a GSM-7
+// substitute, like emoji, are left alone, so the result may still be sent as
+// UCS-2; use Analyze to check.
+//
+// Twilio doesn't publish its full substitution table, so SmartEncode is a
+// preview of the common cases, and the message Twilio sends may differ.
+func SmartEncode(body string) string {
+	var b strings.Builder
+	b.Grow(len(body))
+	for _, r := range body {
+		if sub, ok := smartEncodings[r]; ok {
+			b.WriteString(sub)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,187 @@
+// Package smsencoding calculates the encoding and number of segments of an SMS
+// body before it's sent, the way Twilio does.
+//
+// A body that only uses characters in the GSM-7 alphabet is sent in 7-bit
+// septets, 160 to a message. A body with any other character, like an emoji
+// or a curly quote, is sent as UCS-2, with only 70 characters to a message.
+// Longer bodies are split into segments of 153 septets or 67 UCS-2
+// characters, and each segment is billed as a message:
+//
+//	a := smsencoding.Analyze("Your order has shipped 📦")
+//	fmt.Println(a.Encoding, a.Segments, string(a.NonGSM))
+//	// UCS-2 1 📦
+//
+// Multiply Segments by a price from the Pricing API to estimate the cost of a
+// message; see Analysis.Price. SmartEncode previews the substitutions Twilio
+// makes when Smart Encoding is enabled on a Messaging Service.
+//
+// For more information, see
+// https://www.twilio.com/docs/glossary/what-sms-character-limit.
+package smsencoding
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// An Encoding is the character encoding used to send an SMS.
+type Encoding string
+
+const (
+	// GSM7 is the GSM 03.38 7-bit default alphabet.
+	GSM7 = Encoding("GSM-7")
+	// UCS2 is the 16-bit encoding used for bodies with any character outside
+	// the GSM-7 alphabet.
+	UCS2 = Encoding("UCS-2")
+)
+
+// The number of encoding units (septets for GSM-7, UTF-16 code units for
+// UCS-2) in a message that fits in a single segment, and in each segment of a
+// longer message, which loses some space to the concatenation header.
+const (
+	GSM7SingleSegment = 160
+	GSM7Segment       = 153
+	UCS2SingleSegment = 70
+	UCS2Segment       = 67
+)
+
+// gsm7 is the GSM 03.38 basic character set, without the escape character.
+const gsm7 = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsm7Extended is the GSM 03.38 extension table. Each of these characters is
+// sent as an escape character followed by the character, and takes two
+// septets.
+const gsm7Extended = "\f^{}\\[~]|€"
+
+var basic, extended = runeSet(gsm7), runeSet(gsm7Extended)
+
+func runeSet(s string) map[rune]bool {
+	m := make(map[rune]bool, len(s))
+	for _, r := range s {
+		m[r] = true
+	}
+	return m
+}
+
+// IsGSM7 reports whether r is in the GSM-7 basic character set or its
+// extension table.
+func IsGSM7(r rune) bool {
+	return basic[r] || extended[r]
+}
+
+// IsGSM7Extended reports whether r is in the GSM-7 extension table, and takes
+// two septets to send.
+func IsGSM7Extended(r rune) bool {
+	return extended[r]
+}
+
+// An Analysis describes how an SMS body will be encoded and split into
+// segments.
+type Analysis struct {
+	Encoding Encoding
+	// Characters is the number of Unicode characters in the body.
+	Characters int
+	// Units is the length of the body in the units of Encoding: septets for
+	// GSM-7, where characters in the extension table count twice, or UTF-16
+	// code units for UCS-2, where characters outside the Basic Multilingual
+	// Plane, like most emoji, count twice.
+	Units int
+	// Segments is the number of messages the body will be sent as, and billed
+	// for. It's zero for an empty body.
+	Segments int
+	// NonGSM lists the characters that aren't in the GSM-7 alphabet and forced
+	// the body to be sent as UCS-2, in the order they first appear. It's empty
+	// if Encoding is GSM7.
+	NonGSM []rune
+	// Extended lists the characters in the GSM-7 extension table, in the
+	// order they first appear. Each takes two septets in a GSM-7 body.
+	Extended []rune
+}
+
+// Analyze returns the encoding and number of segments Twilio will use to send
+// body.
+//
+// Like Twilio, Analyze never splits a two-septet extended character or a
+// UTF-16 surrogate pair across segments, so a long body can take more
+// segments than Units divided by the segment size.
+func Analyze(body string) *Analysis {
+	a := &Analysis{Encoding: GSM7}
+	seen := make(map[rune]bool)
+	for _, r := range body {
+		a.Characters++
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		switch {
+		case extended[r]:
+			a.Extended = append(a.Extended, r)
+		case !basic[r]:
+			a.NonGSM = append(a.NonGSM, r)
+		}
+	}
+	if len(a.NonGSM) > 0 {
+		a.Encoding = UCS2
+	}
+	single, multi := GSM7SingleSegment, GSM7Segment
+	if a.Encoding == UCS2 {
+		single, multi = UCS2SingleSegment, UCS2Segment
+	}
+	// First pack the body into multipart segments, then check if it would
+	// have fit in a single message.
+	used := 0
+	for _, r := range body {
+		n := a.width(r)
+		a.Units += n
+		if a.Segments == 0 || used+n > multi {
+			a.Segments++
+			used = 0
+		}
+		used += n
+	}
+	if a.Units <= single && a.Units > 0 {
+		a.Segments = 1
+	}
+	return a
+}
+
+// width returns the number of units r takes in the encoding of a.
+func (a *Analysis) width(r rune) int {
+	if a.Encoding == UCS2 {
+		// Encoded as a UTF-16 surrogate pair.
+		if r > 0xFFFF {
+			return 2
+		}
+		return 1
+	}
+	if extended[r] {
+		return 2
+	}
+	return 1
+}
+
+// Price returns the cost of sending the body, given perSegment, a decimal price
+// for a single message like the CurrentPrice of a twilio.InboundPrice. The
+// result has the same number of decimal places as perSegment. A negative
+// price, as Twilio reports on sent messages, is fine.
+func (a *Analysis) Price(perSegment string) (string, error) {
+	p, ok := new(big.Rat).SetString(perSegment)
+	if !ok {
+		return "", errors.New("smsencoding: invalid price " + strconv.Quote(perSegment))
+	}
+	places := 0
+	if i := strings.IndexByte(perSegment, '.'); i >= 0 {
+		places = len(perSegment) - i - 1
+	}
+	p.Mul(p, big.NewRat(int64(a.Segments), 1))
+	return p.FloatString(places), nil
+}
+
+// Segments returns the number of segments Twilio will send body in. It's
+// shorthand for Analyze(body).Segments.
+func Segments(body string) int {
+	return Analyze(body).Segments
+}
@@ -0,0 +1,121 @@
+package smsencoding
+
+import (
+	"strings"
+	"testing"
+)
+
+var analyzeTests = []struct {
+	name       string
+	body       string
+	encoding   Encoding
+	units      int
+	segments   int
+	nonGSM     string
+	extended   string
+	characters int
+}{
+	{"empty", "", GSM7, 0, 0, "", "", 0},
+	{"ascii", "hello world", GSM7, 11, 1, "", "", 11},
+	{"non-gsm accents", "Señor, ça coûte 5€", UCS2, 18, 1, "çû", "€", 18},
+	{"gsm single max", strings.Repeat("a", 160), GSM7, 160, 1, "", "", 160},
+	{"gsm two segments", strings.Repeat("a", 161), GSM7, 161, 2, "", "", 161},
+	{"gsm three segments", strings.Repeat("a", 307), GSM7, 307, 3, "", "", 307},
+	{"extended", "{}" + strings.Repeat("a", 156), GSM7, 160, 1, "", "{}", 158},
+	{"extended overflow", "{}" + strings.Repeat("a", 157), GSM7, 161, 2, "", "{}", 159},
+	// The euro sign at septets 153-154 doesn't fit in the first segment.
+	{"extended not split", strings.Repeat("a", 152) + "€" + strings.Repeat("a", 152), GSM7, 306, 3, "", "€", 305},
+	{"ucs2", "naïve", UCS2, 5, 1, "ï", "", 5},
+	{"ucs2 single max", strings.Repeat("й", 70), UCS2, 70, 1, "й", "", 70},
+	{"ucs2 two segments", strings.Repeat("й", 71), UCS2, 71, 2, "й", "", 71},
+	{"emoji", "ship 📦", UCS2, 7, 1, "📦", "", 6},
+	// The emoji at code units 67-68 doesn't fit in the first segment.
+	{"surrogate not split", strings.Repeat("й", 66) + "📦" + strings.Repeat("й", 66), UCS2, 134, 3, "й📦", "", 133},
+	{"curly quote", "it’s", UCS2, 4, 1, "’", "", 4},
+	{"extended in ucs2", "€ ✓", UCS2, 3, 1, "✓", "€", 3},
+}
+
+func TestAnalyze(t *testing.T) {
+	t.Parallel()
+	for _, tt := range analyzeTests {
+		a := Analyze(tt.body)
+		if a.Encoding != tt.encoding {
+			t.Errorf("%s: Encoding: got %s, want %s", tt.name, a.Encoding, tt.encoding)
+		}
+		if a.Units != tt.units {
+			t.Errorf("%s: Units: got %d, want %d", tt.name, a.Units, tt.units)
+		}
+		if a.Segments != tt.segments {
+			t.Errorf("%s: Segments: got %d, want %d", tt.name, a.Segments, tt.segments)
+		}
+		if a.Characters != tt.characters {
+			t.Errorf("%s: Characters: got %d, want %d", tt.name, a.Characters, tt.characters)
+		}
+		if string(a.NonGSM) != tt.nonGSM {
+			t.Errorf("%s: NonGSM: got %q, want %q", tt.name, string(a.NonGSM), tt.nonGSM)
+		}
+		if string(a.Extended) != tt.extended {
+			t.Errorf("%s: Extended: got %q, want %q", tt.name, string(a.Extended), tt.extended)
+		}
+	}
+}
+
+func TestIsGSM7(t *testing.T) {
+	t.Parallel()
+	for _, r := range "@£$¥èéùìòÇØøÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ¤¡ÄÖÑÜ§¿äöñüà^{}\\[~]|€\n\r\f" {
+		if !IsGSM7(r) {
+			t.Errorf("expected %q to be GSM-7", r)
+		}
+	}
+	for _, r := range "`’—ç😀 " {
+		if IsGSM7(r) {
+			t.Errorf("expected %q not to be GSM-7", r)
+		}
+	}
+	if !IsGSM7Extended('€') || IsGSM7Extended('a') {
+		t.Error("bad IsGSM7Extended")
+	}
+}
+
+func TestSmartEncode(t *testing.T) {
+	t.Parallel()
+	in := "“Don’t” – wait… it’s ½ price​ 😀"
+	want := `"Don't" - wait... it's 1/2 price 😀`
+	if got := SmartEncode(in); got != want {
+		t.Errorf("SmartEncode: got %q, want %q", got, want)
+	}
+	if a := Analyze(SmartEncode("“Don’t” – wait…")); a.Encoding != GSM7 {
+		t.Errorf("expected smart encoded body to be GSM-7, got %s (%q)", a.Encoding, string(a.NonGSM))
+	}
+	// Every substitution should be GSM-7.
+	for r, sub := range smartEncodings {
+		if IsGSM7(r) {
+			t.Errorf("%q is already GSM-7", r)
+		}
+		if a := Analyze(sub); a.Encoding != GSM7 {
+			t.Errorf("substitution for %q is not GSM-7: %q", r, sub)
+		}
+	}
+}
+
+func TestPrice(t *testing.T) {
+	t.Parallel()
+	a := Analyze(strings.Repeat("a", 200))
+	price, err := a.Price("0.0079")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price != "0.0158" {
+		t.Errorf("expected 0.0158, got %s", price)
+	}
+	price, err = a.Price("-0.00750")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price != "-0.01500" {
+		t.Errorf("expected -0.01500, got %s", price)
+	}
+	if _, err := a.Price("free"); err == nil {
+		t.Error("expected an error for an invalid price")
+	}
+}
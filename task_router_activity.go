@@ -48,6 +48,39 @@ func (r *ActivityService) Create(ctx context.Context, data url.Values) (*Activit
 	return activity, err
 }
 
+// ActivityParams are the parameters for creating an Activity.
+type ActivityParams struct {
+	FriendlyName string
+	// Available is whether Workers in this Activity can be assigned Tasks.
+	// Nil uses Twilio's default, which is true.
+	Available *bool
+}
+
+// Validate returns a *ValidationError if p has no FriendlyName.
+func (p *ActivityParams) Validate() error {
+	if p.FriendlyName == "" {
+		return &ValidationError{Field: "FriendlyName", Message: "a friendly name is required"}
+	}
+	return nil
+}
+
+// Values returns the form values Twilio expects for p.
+func (p *ActivityParams) Values() url.Values {
+	v := url.Values{}
+	setString(v, "FriendlyName", p.FriendlyName)
+	setBool(v, "Available", p.Available)
+	return v
+}
+
+// CreateWithParams validates p and creates an Activity. If p is invalid, it
+// returns a *ValidationError without making a request.
+func (r *ActivityService) CreateWithParams(ctx context.Context, p *ActivityParams) (*Activity, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return r.Create(ctx, p.Values())
+}
+
 // Delete deletes an Activity.
 //
 // See https://www.twilio.com/docs/taskrouter/api/activities#action-delete for
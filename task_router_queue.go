@@ -46,6 +46,55 @@ func (r *TaskQueueService) Create(ctx context.Context, data url.Values) (*TaskQu
 	return queue, err
 }
 
+// TaskQueueParams are the parameters for creating a TaskQueue.
+type TaskQueueParams struct {
+	FriendlyName string
+	// TargetWorkers is an expression that selects the Workers for the
+	// TaskQueue, e.g. `languages HAS "english"`.
+	TargetWorkers      string
+	MaxReservedWorkers int
+	// TaskOrder is "FIFO" or "LIFO".
+	TaskOrder              string
+	AssignmentActivitySid  string
+	ReservationActivitySid string
+}
+
+// Validate returns a *ValidationError if p has no FriendlyName, or an invalid
+// TaskOrder.
+func (p *TaskQueueParams) Validate() error {
+	if p.FriendlyName == "" {
+		return &ValidationError{Field: "FriendlyName", Message: "a friendly name is required"}
+	}
+	if p.TaskOrder != "" && p.TaskOrder != "FIFO" && p.TaskOrder != "LIFO" {
+		return &ValidationError{Field: "TaskOrder", Message: `must be "FIFO" or "LIFO"`}
+	}
+	if p.MaxReservedWorkers < 0 || p.MaxReservedWorkers > 50 {
+		return &ValidationError{Field: "MaxReservedWorkers", Message: "must be between 1 and 50"}
+	}
+	return nil
+}
+
+// Values returns the form values Twilio expects for p.
+func (p *TaskQueueParams) Values() url.Values {
+	v := url.Values{}
+	setString(v, "FriendlyName", p.FriendlyName)
+	setString(v, "TargetWorkers", p.TargetWorkers)
+	setInt(v, "MaxReservedWorkers", p.MaxReservedWorkers)
+	setString(v, "TaskOrder", p.TaskOrder)
+	setString(v, "AssignmentActivitySid", p.AssignmentActivitySid)
+	setString(v, "ReservationActivitySid", p.ReservationActivitySid)
+	return v
+}
+
+// CreateWithParams validates p and creates a TaskQueue. If p is invalid, it
+// returns a *ValidationError without making a request.
+func (r *TaskQueueService) CreateWithParams(ctx context.Context, p *TaskQueueParams) (*TaskQueue, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return r.Create(ctx, p.Values())
+}
+
 func (r *TaskQueueService) Delete(ctx context.Context, sid string) error {
 	return r.client.DeleteResource(ctx, "Workspaces/"+r.workspaceSid+"/"+TaskQueuePathPart, sid)
 }
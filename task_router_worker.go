@@ -52,6 +52,41 @@ func (r *WorkerService) Create(ctx context.Context, data url.Values) (*Worker, e
 	return worker, err
 }
 
+// WorkerParams are the parameters for creating a Worker.
+type WorkerParams struct {
+	FriendlyName string
+	ActivitySid  string
+	// Attributes is a JSON object, for example: `{"type": "support"}`.
+	Attributes string
+}
+
+// Validate returns a *ValidationError if p has no FriendlyName, or Attributes
+// that aren't a JSON object.
+func (p *WorkerParams) Validate() error {
+	if p.FriendlyName == "" {
+		return &ValidationError{Field: "FriendlyName", Message: "a friendly name is required"}
+	}
+	return validateJSON("Attributes", p.Attributes)
+}
+
+// Values returns the form values Twilio expects for p.
+func (p *WorkerParams) Values() url.Values {
+	v := url.Values{}
+	setString(v, "FriendlyName", p.FriendlyName)
+	setString(v, "ActivitySid", p.ActivitySid)
+	setString(v, "Attributes", p.Attributes)
+	return v
+}
+
+// CreateWithParams validates p and creates a Worker. If p is invalid, it
+// returns a *ValidationError without making a request.
+func (r *WorkerService) CreateWithParams(ctx context.Context, p *WorkerParams) (*Worker, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return r.Create(ctx, p.Values())
+}
+
 // Delete deletes a Worker.
 //
 // See https://www.twilio.com/docs/taskrouter/api/workers#action-delete for more.
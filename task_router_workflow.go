@@ -55,6 +55,57 @@ func (r *WorkflowService) Create(ctx context.Context, data url.Values) (*Workflo
 	return workflow, err
 }
 
+// WorkflowParams are the parameters for creating a Workflow.
+type WorkflowParams struct {
+	FriendlyName string
+	// Configuration is a JSON object describing how Tasks are routed; see
+	// Workflow.Configuration. It's required.
+	Configuration                 string
+	AssignmentCallbackURL         string
+	FallbackAssignmentCallbackURL string
+	// TaskReservationTimeout is how many seconds a Worker has to accept a
+	// Task, up to 86400.
+	TaskReservationTimeout int
+}
+
+// Validate returns a *ValidationError if p has no FriendlyName, or a missing
+// or invalid Configuration.
+func (p *WorkflowParams) Validate() error {
+	if p.FriendlyName == "" {
+		return &ValidationError{Field: "FriendlyName", Message: "a friendly name is required"}
+	}
+	if p.Configuration == "" {
+		return &ValidationError{Field: "Configuration", Message: "a configuration is required"}
+	}
+	if err := validateJSON("Configuration", p.Configuration); err != nil {
+		return err
+	}
+	if p.TaskReservationTimeout < 0 || p.TaskReservationTimeout > 86400 {
+		return &ValidationError{Field: "TaskReservationTimeout", Message: "must be between 1 and 86400 seconds"}
+	}
+	return nil
+}
+
+// Values returns the form values Twilio expects for p.
+func (p *WorkflowParams) Values() url.Values {
+	v := url.Values{}
+	setString(v, "FriendlyName", p.FriendlyName)
+	setString(v, "Configuration", p.Configuration)
+	setString(v, "AssignmentCallbackUrl", p.AssignmentCallbackURL)
+	setString(v, "FallbackAssignmentCallbackUrl", p.FallbackAssignmentCallbackURL)
+	setInt(v, "TaskReservationTimeout", p.TaskReservationTimeout)
+	return v
+}
+
+// CreateWithParams validates p and creates a Workflow. If p is invalid, it
+// returns a *ValidationError without making a request.
+func (r *WorkflowService) CreateWithParams(ctx context.Context, p *WorkflowParams) (*Workflow, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return r.Create(ctx, p.Values())
+}
+
 // Delete deletes a Workflow.
 //
 // See https://www.twilio.com/docs/taskrouter/api/workflows#action-delete for more.
@@ -0,0 +1,27 @@
+package twiliootel_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	twilio "github.com/kevinburke/twilio-go"
+	"github.com/kevinburke/twilio-go/twiliootel"
+)
+
+func ExampleMiddleware() {
+	client := twilio.NewClient(os.Getenv("TWILIO_ACCOUNT_SID"), os.Getenv("TWILIO_AUTH_TOKEN"), nil)
+	// Use the global TracerProvider and MeterProvider.
+	client.Use(twiliootel.Middleware(nil))
+	msg, err := client.Messages.Get(context.TODO(), "SM123")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(msg.Status)
+}
+
+func ExampleResource() {
+	fmt.Println(twiliootel.Resource("/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Messages.json"))
+	// Output: /Accounts/{sid}/Messages
+}
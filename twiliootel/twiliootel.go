@@ -0,0 +1,176 @@
+// Package twiliootel instruments a twilio.Client with OpenTelemetry tracing
+// and metrics.
+//
+// Middleware returns a twilio.Middleware that starts a client span for every
+// API request, and records its duration and result:
+//
+//	client := twilio.NewClient(sid, token, nil)
+//	client.Use(twiliootel.Middleware(nil))
+//
+// Client.Use adds the Middleware to the product clients created by NewClient
+// (Monitor, Pricing, Wireless, TaskRouter, Insights, etc) as well, and page
+// iterators fetch each page through the same Client, so every request is
+// instrumented.
+//
+// Spans are named after the method and the resource template, with the API
+// version removed and sids and phone numbers replaced by placeholders, e.g.
+// "POST /Accounts/{sid}/Messages". If the API returns an error, the span's
+// "twilio.error_code" attribute is set to the Twilio error code.
+//
+// The twilio.client.request.duration histogram records the duration of each
+// request in seconds, and the twilio.client.requests counter counts requests.
+// Both have "http.request.method", "twilio.resource" and
+// "http.response.status_code" attributes, and a "twilio.error_code" attribute
+// for failed requests.
+package twiliootel
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	twilio "github.com/kevinburke/twilio-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/kevinburke/twilio-go/twiliootel"
+
+// Attribute keys set on spans and metrics, in addition to the standard
+// "http.request.method", "http.response.status_code" and "server.address".
+const (
+	// ResourceKey is the resource template, e.g. "/Accounts/{sid}/Messages".
+	ResourceKey = attribute.Key("twilio.resource")
+	// ErrorCodeKey is the Twilio error code returned by the API, e.g. 21211.
+	ErrorCodeKey = attribute.Key("twilio.error_code")
+)
+
+// Config configures the Middleware. The zero value uses the global
+// TracerProvider and MeterProvider.
+type Config struct {
+	// TracerProvider creates spans. If nil, otel.GetTracerProvider() is
+	// used.
+	TracerProvider trace.TracerProvider
+	// MeterProvider records metrics. If nil, otel.GetMeterProvider() is
+	// used.
+	MeterProvider metric.MeterProvider
+}
+
+// Middleware returns a twilio.Middleware that traces and records metrics for
+// each request. cfg may be nil.
+func Middleware(cfg *Config) twilio.Middleware {
+	if cfg == nil {
+		cfg = new(Config)
+	}
+	tp := cfg.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	mp := cfg.MeterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	tracer := tp.Tracer(instrumentationName, trace.WithInstrumentationVersion(twilio.Version))
+	meter := mp.Meter(instrumentationName, metric.WithInstrumentationVersion(twilio.Version))
+	// On error, the meter returns a no-op instrument, so requests are still
+	// traced.
+	duration, err := meter.Float64Histogram("twilio.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of Twilio API requests."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	requests, err := meter.Int64Counter("twilio.client.requests",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Number of Twilio API requests."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next twilio.Handler) twilio.Handler {
+		return func(r *twilio.Request) (*http.Response, error) {
+			resource := Resource(r.Path)
+			attrs := []attribute.KeyValue{
+				attribute.String("http.request.method", r.Method),
+				ResourceKey.String(resource),
+			}
+			ctx, span := tracer.Start(r.HTTPRequest.Context(), r.Method+" "+resource,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(attribute.String("server.address", r.HTTPRequest.URL.Hostname())),
+			)
+			defer span.End()
+			// Let instrumented Transports create child spans.
+			r.HTTPRequest = r.HTTPRequest.WithContext(ctx)
+
+			start := time.Now()
+			resp, err := next(r)
+			elapsed := time.Since(start)
+
+			var result []attribute.KeyValue
+			if resp != nil {
+				result = append(result, attribute.Int("http.response.status_code", resp.StatusCode))
+			}
+			if err != nil {
+				if terr, ok := twilio.AsError(err); ok {
+					result = append(result, ErrorCodeKey.Int(int(terr.Code)))
+				} else {
+					result = append(result, attribute.String("error.type", fmt.Sprintf("%T", err)))
+				}
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.SetAttributes(result...)
+			opt := metric.WithAttributes(append(attrs, result...)...)
+			duration.Record(ctx, elapsed.Seconds(), opt)
+			requests.Add(ctx, 1, opt)
+			return resp, err
+		}
+	}
+}
+
+var (
+	versionRx = regexp.MustCompile(`^(v[0-9]+|[0-9]{4}-[0-9]{2}-[0-9]{2})$`)
+	sidRx     = regexp.MustCompile(`^[A-Z]{2}[0-9a-f]{32}$`)
+	numberRx  = regexp.MustCompile(`^(\+|%2B)?[0-9]{7,}$`)
+	// formattedRx matches a phone number with formatting, like the
+	// "(415)%20123-4567" a Lookup request may be made with.
+	formattedRx = regexp.MustCompile(`^[0-9()+%. -]{7,}$`)
+)
+
+// Resource returns the resource template for an API path: the API version
+// and any ".json" extension are removed, and sids and phone numbers are
+// replaced with "{sid}" and "{number}". A segment after "PhoneNumbers" made of
+// digits and formatting, like the national number in a Lookup request, is
+// treated as a phone number too, while words like the "Countries" of a Pricing
+// request are kept. For example,
+//
+//	/2010-04-01/Accounts/AC123.../Messages/SM456....json
+//
+// becomes "/Accounts/{sid}/Messages/{sid}".
+func Resource(path string) string {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(parts) > 0 && versionRx.MatchString(parts[0]) {
+		parts = parts[1:]
+	}
+	for i, part := range parts {
+		if i == len(parts)-1 {
+			part = strings.TrimSuffix(part, ".json")
+		}
+		switch {
+		case sidRx.MatchString(part):
+			part = "{sid}"
+		case numberRx.MatchString(part), i > 0 && parts[i-1] == "PhoneNumbers" && formattedRx.MatchString(part):
+			part = "{number}"
+		}
+		parts[i] = part
+	}
+	return "/" + strings.Join(parts, "/")
+}
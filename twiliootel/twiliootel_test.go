@@ -0,0 +1,209 @@
+package twiliootel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	twilio "github.com/kevinburke/twilio-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const accountSid = "AC58f1e8f2b1c6b88ca90a012a4be0c279"
+
+var messagePage = []byte(`{
+    "first_page_uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Messages.json?PageSize=1&Page=0",
+    "next_page_uri": "/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Messages.json?PageSize=1&Page=1&PageToken=PASMc49f620580b24424bcfa885b1f741130",
+    "page": 0,
+    "page_size": 1,
+    "messages": [{"sid": "SMc49f620580b24424bcfa885b1f741130", "status": "delivered"}]
+}`)
+
+var lastMessagePage = []byte(`{
+    "next_page_uri": null,
+    "page": 1,
+    "page_size": 1,
+    "messages": [{"sid": "SM37ec5d7a7e3b2d6e2e6ac2b3e6de2d6c", "status": "delivered"}]
+}`)
+
+func newServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/Messages/SM00000000000000000000000000000000.json"):
+			w.WriteHeader(404)
+			w.Write([]byte(`{"code": 20404, "message": "The requested resource was not found", "status": 404}`))
+		case r.URL.Query().Get("Page") == "1":
+			w.Write(lastMessagePage)
+		default:
+			w.Write(messagePage)
+		}
+	}))
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+	s := newServer()
+	defer s.Close()
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	cfg := &Config{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	}
+	client := twilio.NewClient(accountSid, "token", nil)
+	client.Base = s.URL
+	client.Use(Middleware(cfg))
+
+	iter := client.Messages.GetPageIterator(nil)
+	for {
+		_, err := iter.Next(context.Background())
+		if err == twilio.NoMoreResults {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := client.Messages.Get(context.Background(), "SM00000000000000000000000000000000")
+	if !twilio.IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(ended))
+	}
+	for i, want := range []string{"GET /Accounts/{sid}/Messages", "GET /Accounts/{sid}/Messages", "GET /Accounts/{sid}/Messages/{sid}"} {
+		if name := ended[i].Name(); name != want {
+			t.Errorf("span %d: got name %q, want %q", i, name, want)
+		}
+	}
+	notFound := ended[2]
+	if notFound.Status().Code != codes.Error {
+		t.Errorf("expected error status, got %v", notFound.Status())
+	}
+	if v, ok := attr(notFound.Attributes(), ErrorCodeKey); !ok || v.AsInt64() != 20404 {
+		t.Errorf("expected error code attribute 20404, got %v", v)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]int64)
+	var histogramCount uint64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					status, _ := dp.Attributes.Value("http.response.status_code")
+					key := status.Emit()
+					if code, ok := dp.Attributes.Value(ErrorCodeKey); ok {
+						key += "/" + code.Emit()
+					}
+					counts[key] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					histogramCount += dp.Count
+				}
+			}
+		}
+	}
+	if counts["200"] != 2 || counts["404/20404"] != 1 {
+		t.Errorf("bad request counts: %v", counts)
+	}
+	if histogramCount != 3 {
+		t.Errorf("expected 3 durations, got %d", histogramCount)
+	}
+}
+
+func TestMiddlewareProductClients(t *testing.T) {
+	t.Parallel()
+	s := newServer()
+	defer s.Close()
+	spans := tracetest.NewSpanRecorder()
+	cfg := &Config{TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))}
+	client := twilio.NewClient(accountSid, "token", nil)
+	client.Wireless.Base = s.URL
+	client.Use(Middleware(cfg))
+	// The response is not a Sim, but that doesn't matter here.
+	client.Wireless.Sims.Get(context.Background(), "DEb0d4d8e7c1f5f9e8a1b6c1b0c2c3d4e5")
+	ended := spans.Ended()
+	if len(ended) != 1 || ended[0].Name() != "GET /Sims/{sid}" {
+		t.Fatalf("expected one span for the Wireless request, got %v", ended)
+	}
+	if v, ok := attr(ended[0].Attributes(), "server.address"); !ok || v.AsString() != "127.0.0.1" {
+		t.Errorf("bad server.address: %v", v)
+	}
+}
+
+func TestMiddlewareLookupNationalNumber(t *testing.T) {
+	t.Parallel()
+	s := newServer()
+	defer s.Close()
+	spans := tracetest.NewSpanRecorder()
+	cfg := &Config{TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))}
+	client := twilio.NewClient(accountSid, "token", nil)
+	client.Lookup.Base = s.URL
+	client.Use(Middleware(cfg))
+	client.Lookup.LookupPhoneNumbers.Get(context.Background(), "4151234567", nil)
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("expected one span for the Lookup request, got %d", len(ended))
+	}
+	if name := ended[0].Name(); name != "GET /PhoneNumbers/{number}" {
+		t.Errorf("bad span name: %q", name)
+	}
+	for _, kv := range ended[0].Attributes() {
+		if strings.Contains(kv.Value.Emit(), "4151234567") {
+			t.Errorf("phone number leaked in attribute %s: %s", kv.Key, kv.Value.Emit())
+		}
+	}
+}
+
+func attr(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+var resourceTests = []struct {
+	path string
+	want string
+}{
+	{"/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Messages.json", "/Accounts/{sid}/Messages"},
+	{"/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Calls/CA47b862ce3b99a6d79939320a9aa54a02/Recordings.json", "/Accounts/{sid}/Calls/{sid}/Recordings"},
+	{"/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/Messages/MM89a8c4a6891c53054e9cd604922bfb61/Media/ME4f366233682e811f63f73220bc07fc34", "/Accounts/{sid}/Messages/{sid}/Media/{sid}"},
+	{"/v1/Alerts", "/Alerts"},
+	{"/v1/Workspaces/WS7a2c3b0dcdc1b8e6c6f8a5c4b3d2e1f0/Workers/WK7a2c3b0dcdc1b8e6c6f8a5c4b3d2e1f0", "/Workspaces/{sid}/Workers/{sid}"},
+	{"/v1/PhoneNumbers/+14105551234", "/PhoneNumbers/{number}"},
+	{"/v1/PhoneNumbers/4151234567", "/PhoneNumbers/{number}"},
+	{"/v1/PhoneNumbers/(415)%20123-4567", "/PhoneNumbers/{number}"},
+	{"/v2/PhoneNumbers/Countries", "/PhoneNumbers/Countries"},
+	{"/v2/PhoneNumbers/Countries/US", "/PhoneNumbers/Countries/US"},
+	{"/2010-04-01/Accounts/AC58f1e8f2b1c6b88ca90a012a4be0c279/IncomingPhoneNumbers/Local.json", "/Accounts/{sid}/IncomingPhoneNumbers/Local"},
+	{"/v2/Voice/CA47b862ce3b99a6d79939320a9aa54a02/Summary", "/Voice/{sid}/Summary"},
+	{"/v1/Sims/my-tracker", "/Sims/my-tracker"},
+}
+
+func TestResource(t *testing.T) {
+	t.Parallel()
+	for _, tt := range resourceTests {
+		if got := Resource(tt.path); got != tt.want {
+			t.Errorf("Resource(%q): got %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package twiliotest
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	twilio "github.com/kevinburke/twilio-go"
+)
+
+var callbackClient = &http.Client{Timeout: 10 * time.Second}
+
+// async runs f in a new goroutine, which Wait and Close wait for.
+func (s *Server) async(f func()) {
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+		f()
+	}()
+}
+
+// post makes a status callback request to callbackURL, signed with
+// s.AuthToken. s.mu must not be held.
+func (s *Server) post(callbackURL string, form url.Values) {
+	err := s.doPost(callbackURL, form)
+	if err != nil {
+		s.mu.Lock()
+		if s.callbackErr == nil {
+			s.callbackErr = err
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *Server) doPost(callbackURL string, form url.Values) error {
+	req, err := http.NewRequest("POST", callbackURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	// Sign the URL the request is actually sent to.
+	if req.URL.Path == "" {
+		req.URL.Path = "/"
+		callbackURL = req.URL.String()
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "TwilioProxy/1.1")
+	req.Header.Set("X-Twilio-Signature", twilio.GetExpectedTwilioSignature("", s.AuthToken, callbackURL, form))
+	resp, err := callbackClient.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("twiliotest: status callback to %s returned %s", callbackURL, resp.Status)
+	}
+	return nil
+}
+
+// deliverMessage moves a new Message through the "sent" and "delivered"
+// statuses, calling callbackURL for each, if it is not empty.
+func (s *Server) deliverMessage(res resource, callbackURL string) {
+	s.async(func() {
+		for _, status := range []twilio.Status{twilio.StatusSent, twilio.StatusDelivered} {
+			s.mu.Lock()
+			now := formatTime(false, time.Now())
+			res["status"] = status
+			res["date_updated"] = now
+			if status == twilio.StatusSent {
+				res["date_sent"] = now
+				res["price"] = "-0.00790"
+				if res["from"] == nil {
+					// A Messaging Service picks a number from its pool.
+					res["from"] = NumberValid
+				}
+			}
+			form := url.Values{
+				"AccountSid":    {s.AccountSid},
+				"ApiVersion":    {twilio.APIVersion},
+				"From":          {res.str("from")},
+				"MessageSid":    {res.str("sid")},
+				"MessageStatus": {string(status)},
+				"SmsSid":        {res.str("sid")},
+				"SmsStatus":     {string(status)},
+				"To":            {res.str("to")},
+			}
+			if serviceSid := res.str("messaging_service_sid"); serviceSid != "" {
+				form.Set("MessagingServiceSid", serviceSid)
+			}
+			s.mu.Unlock()
+			if callbackURL != "" {
+				s.post(callbackURL, form)
+			}
+		}
+	})
+}
+
+// callProgress is the status callback configuration for a Call.
+type callProgress struct {
+	url    string
+	events map[string]bool
+	seq    int
+}
+
+// connectCall rings and answers a new Call, calling its StatusCallback for
+// each of the StatusCallbackEvents in form. The Call stays in progress until
+// it is updated with a Status of "completed".
+func (s *Server) connectCall(res resource, form url.Values) {
+	p := &callProgress{url: form.Get("StatusCallback"), events: make(map[string]bool)}
+	for _, val := range form["StatusCallbackEvent"] {
+		for _, event := range strings.Fields(val) {
+			p.events[event] = true
+		}
+	}
+	if len(p.events) == 0 {
+		p.events["completed"] = true
+	}
+	if s.calls == nil {
+		s.calls = make(map[string]*callProgress)
+	}
+	s.calls[res.str("sid")] = p
+
+	steps := []struct {
+		from, to twilio.Status
+		event    string
+	}{
+		{twilio.StatusQueued, twilio.StatusQueued, "initiated"},
+		{twilio.StatusQueued, twilio.StatusRinging, "ringing"},
+		{twilio.StatusRinging, twilio.StatusInProgress, "answered"},
+	}
+	s.async(func() {
+		for _, step := range steps {
+			s.mu.Lock()
+			// The Call may have been canceled or hung up in the meantime.
+			if twilio.Status(res.str("status")) != step.from {
+				s.mu.Unlock()
+				return
+			}
+			res["status"] = step.to
+			if step.to == twilio.StatusInProgress {
+				res["start_time"] = formatTime(false, time.Now())
+			}
+			form := s.callEvent(res, step.event)
+			s.mu.Unlock()
+			if form != nil {
+				s.post(p.url, form)
+			}
+		}
+	})
+}
+
+// endCall ends the Call with the given status, and calls its StatusCallback.
+// s.mu must be held.
+func (s *Server) endCall(res resource, status twilio.Status) {
+	now := time.Now()
+	res["status"] = status
+	res["end_time"] = formatTime(false, now)
+	duration := 0
+	if start, ok := parseTime(res.str("start_time")); ok {
+		duration = int(now.Sub(start) / time.Second)
+		res["price"] = "-0.01400"
+	}
+	res["duration"] = strconv.Itoa(duration)
+	if form := s.callEvent(res, "completed"); form != nil {
+		callbackURL := s.calls[res.str("sid")].url
+		s.async(func() {
+			s.post(callbackURL, form)
+		})
+	}
+}
+
+// callEvent returns the status callback parameters for the event, or nil if
+// the Call's StatusCallback should not be called for it. s.mu must be held.
+func (s *Server) callEvent(res resource, event string) url.Values {
+	p := s.calls[res.str("sid")]
+	if p == nil || p.url == "" || !p.events[event] {
+		return nil
+	}
+	status := res.str("status")
+	if event == "initiated" {
+		status = event
+	}
+	form := url.Values{
+		"AccountSid":     {s.AccountSid},
+		"ApiVersion":     {twilio.APIVersion},
+		"CallSid":        {res.str("sid")},
+		"CallStatus":     {status},
+		"CallbackSource": {"call-progress-events"},
+		"Direction":      {res.str("direction")},
+		"From":           {res.str("from")},
+		"SequenceNumber": {strconv.Itoa(p.seq)},
+		"Timestamp":      {formatTime(false, time.Now())},
+		"To":             {res.str("to")},
+	}
+	p.seq++
+	if event == "completed" {
+		form.Set("CallDuration", res.str("duration"))
+	}
+	return form
+}
+
+// deliverCommand moves a new Command through the "sent" and "delivered"
+// statuses, calling callbackURL for each, if it is not empty.
+func (s *Server) deliverCommand(res resource, simUniqueName string, callbackURL string) {
+	s.async(func() {
+		for _, status := range []twilio.Status{twilio.StatusSent, twilio.StatusDelivered} {
+			s.mu.Lock()
+			res["status"] = status
+			res["date_updated"] = formatTime(true, time.Now())
+			form := url.Values{
+				"AccountSid":    {s.AccountSid},
+				"ApiVersion":    {"v1"},
+				"Command":       {res.str("command")},
+				"CommandMode":   {res.str("command_mode")},
+				"CommandSid":    {res.str("sid")},
+				"CommandStatus": {string(status)},
+				"Direction":     {res.str("direction")},
+				"SimSid":        {res.str("sim_sid")},
+				"SimUniqueName": {simUniqueName},
+			}
+			s.mu.Unlock()
+			if callbackURL != "" {
+				s.post(callbackURL, form)
+			}
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package twiliotest_test
+
+import (
+	"fmt"
+
+	twilio "github.com/kevinburke/twilio-go"
+	"github.com/kevinburke/twilio-go/twiliotest"
+)
+
+func Example() {
+	server := twiliotest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	msg, err := client.Messages.SendMessage(twiliotest.NumberValid, "+14105551234", "Your order shipped", nil)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(msg.Status)
+
+	_, err = client.Messages.SendMessage(twiliotest.NumberValid, twiliotest.NumberBlocked, "Your order shipped", nil)
+	fmt.Println(twilio.IsUnsubscribed(err))
+
+	server.Wait()
+	fmt.Println(server.Messages()[0].Status)
+	// Output:
+	// queued
+	// true
+	// delivered
+}
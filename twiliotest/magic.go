@@ -0,0 +1,103 @@
+package twiliotest
+
+import (
+	"net/http"
+
+	twilio "github.com/kevinburke/twilio-go"
+)
+
+// Twilio's magic test phone numbers. Requests that use them fail (or succeed)
+// the same way they do when you use Twilio's test credentials.
+//
+// https://www.twilio.com/docs/iam/test-credentials#magic-input
+const (
+	// NumberValid passes all validation, as a "From", "To" or
+	// "PhoneNumber" parameter.
+	NumberValid = "+15005550006"
+	// NumberUnavailable cannot be purchased.
+	NumberUnavailable = "+15005550000"
+	// NumberInvalid is not a valid phone number.
+	NumberInvalid = "+15005550001"
+	// NumberUnroutable cannot be reached as a "To" number.
+	NumberUnroutable = "+15005550002"
+	// NumberNoInternational is in a region your account is not allowed to
+	// send messages or make calls to.
+	NumberNoInternational = "+15005550003"
+	// NumberBlocked is on a blocklist, or has unsubscribed from messages
+	// from your "From" number.
+	NumberBlocked = "+15005550004"
+	// NumberNotOwned is not owned by your account, as a "From" number.
+	NumberNotOwned = "+15005550007"
+	// NumberQueueFull has too many queued messages, as a "From" number.
+	NumberQueueFull = "+15005550008"
+	// NumberNotMobile cannot receive SMS messages.
+	NumberNotMobile = "+15005550009"
+
+	// AreaCodeUnavailable has no phone numbers available to purchase.
+	AreaCodeUnavailable = "533"
+)
+
+func badRequest(code twilio.Code, format string, args ...interface{}) *apiError {
+	return newError(http.StatusBadRequest, code, format, args...)
+}
+
+func messageFromError(from string) *apiError {
+	switch from {
+	case NumberInvalid:
+		return badRequest(twilio.CodeInvalidFromPhoneNumber, "The 'From' number %s is not a valid phone number, shortcode, or alphanumeric sender ID.", from)
+	case NumberNotOwned:
+		return badRequest(21606, "The From phone number %s is not a valid, SMS-capable inbound phone number or short code for your account.", from)
+	case NumberQueueFull:
+		return badRequest(21611, "This 'From' number has exceeded the maximum number of queued messages")
+	}
+	return nil
+}
+
+func messageToError(to string, from string) *apiError {
+	switch to {
+	case NumberInvalid:
+		return badRequest(twilio.CodeInvalidToPhoneNumber, "The 'To' number %s is not a valid phone number.", to)
+	case NumberUnroutable:
+		return badRequest(21612, "The 'To' phone number: %s, is not currently reachable using the 'From' phone number: %s via SMS.", to, from)
+	case NumberNoInternational:
+		return badRequest(21408, "Permission to send an SMS has not been enabled for the region indicated by the 'To' number: %s.", to)
+	case NumberBlocked:
+		return badRequest(twilio.CodeUnsubscribedRecipient, "Attempt to send to unsubscribed recipient")
+	case NumberNotMobile:
+		return badRequest(twilio.CodeNotMobileNumber, "'To' number is not a valid mobile number")
+	}
+	return nil
+}
+
+func callFromError(from string) *apiError {
+	if from == NumberInvalid {
+		return badRequest(twilio.CodeInvalidFromPhoneNumber, "The 'From' number %s is not a valid phone number.", from)
+	}
+	return nil
+}
+
+func callToError(to string) *apiError {
+	switch to {
+	case NumberInvalid:
+		return badRequest(21217, "Phone number %s does not appear to be valid", to)
+	case NumberUnroutable:
+		return badRequest(21214, "'To' phone number %s cannot be reached", to)
+	case NumberNoInternational:
+		return badRequest(21215, "Account not authorized to call %s.", to)
+	case NumberBlocked:
+		return badRequest(21216, "Account not allowed to call %s", to)
+	}
+	return nil
+}
+
+func purchaseError(phoneNumber string, areaCode string) *apiError {
+	switch {
+	case phoneNumber == NumberInvalid:
+		return badRequest(21421, "PhoneNumber %s is invalid.", phoneNumber)
+	case phoneNumber == NumberUnavailable:
+		return badRequest(21422, "PhoneNumber %s is not available.", phoneNumber)
+	case phoneNumber == "" && areaCode == AreaCodeUnavailable:
+		return badRequest(21452, "No phone numbers found in area code %s.", areaCode)
+	}
+	return nil
+}
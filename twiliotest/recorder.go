@@ -0,0 +1,319 @@
+package twiliotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// A Mode determines whether a Recorder makes real requests or replays
+// recorded ones.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette, and never makes a
+	// network request.
+	ModeReplay Mode = iota
+	// ModeRecord makes real requests and writes each request and response
+	// to the cassette, replacing its previous contents.
+	ModeRecord
+)
+
+// A Recorder is an http.RoundTripper that records API traffic to a cassette
+// file, and replays it later without a network connection:
+//
+//	mode := twiliotest.ModeReplay
+//	if os.Getenv("TWILIO_RECORD") == "true" {
+//		mode = twiliotest.ModeRecord
+//	}
+//	rec, err := twiliotest.NewRecorder("testdata/send_message.jsonl", mode)
+//	// handle err
+//	defer rec.Close()
+//	client := twilio.NewClient(sid, token, &http.Client{Transport: rec})
+//
+// A cassette is a JSONL file with one request and response on each line.
+// Before anything is written, Account sids are replaced with AccountSid,
+// phone numbers are replaced with numbers in the +1500555 range, the auth
+// token is removed, and request headers (including Authorization) are
+// dropped, so cassettes are safe to check in. The same number is always
+// replaced with the same fake number, so tests can use real numbers in
+// replay mode.
+//
+// In replay mode, a request matches the first unused recorded request with
+// the same method, host, path, query and form body, after redaction. If no
+// recorded request matches, RoundTrip returns an error, as does Close.
+type Recorder struct {
+	// Transport makes requests in ModeRecord. If nil,
+	// http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	mode Mode
+	path string
+
+	mu           sync.Mutex
+	file         *os.File
+	enc          *json.Encoder
+	interactions []*interaction
+	used         []bool
+	err          error
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// NewRecorder returns a Recorder that records to, or replays from, the
+// cassette at path. In ModeReplay the cassette must exist.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path}
+	switch mode {
+	case ModeRecord:
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		r.file = f
+		r.enc = json.NewEncoder(f)
+	case ModeReplay:
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		dec := json.NewDecoder(f)
+		for {
+			in := new(interaction)
+			if err := dec.Decode(in); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("twiliotest: reading cassette %s: %v", path, err)
+			}
+			r.interactions = append(r.interactions, in)
+		}
+		r.used = make([]bool, len(r.interactions))
+	default:
+		return nil, fmt.Errorf("twiliotest: unknown Recorder mode %d", mode)
+	}
+	return r, nil
+}
+
+// Client returns an *http.Client that uses r as its Transport, for use with
+// twilio.NewClient.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	var secrets []string
+	if _, token, ok := req.BasicAuth(); ok && token != "" {
+		secrets = append(secrets, token)
+	}
+	recorded := recordedRequest{
+		Method: req.Method,
+		URL:    redactURL(req.URL, secrets),
+		Body:   redactForm(string(body), secrets),
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	header := make(http.Header, len(resp.Header))
+	for k, vals := range resp.Header {
+		if k == "Set-Cookie" {
+			continue
+		}
+		for _, v := range vals {
+			header.Add(k, redact(v, secrets))
+		}
+	}
+	in := &interaction{
+		Request: recorded,
+		Response: recordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       redact(string(respBody), secrets),
+		},
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.enc.Encode(in); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded recordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.interactions {
+		if r.used[i] || in.Request != recorded {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	err := fmt.Errorf("twiliotest: no unused recording in %s matches %s %s", r.path, recorded.Method, recorded.URL)
+	if recorded.Body != "" {
+		err = fmt.Errorf("%v with body %s", err, recorded.Body)
+	}
+	if r.err == nil {
+		r.err = err
+	}
+	return nil, err
+}
+
+// Close closes the cassette. In ModeReplay, it returns the error for the
+// first request that did not match a recording, if there was one.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file != nil {
+		if err := r.file.Close(); err != nil {
+			return err
+		}
+		r.file = nil
+	}
+	return r.err
+}
+
+// Unused returns the number of recorded requests that have not been replayed.
+// A nonzero value after a test finishes may mean the code under test made
+// fewer requests than it did when the cassette was recorded.
+func (r *Recorder) Unused() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, used := range r.used {
+		if !used {
+			n++
+		}
+	}
+	return n
+}
+
+var (
+	accountSidRx = regexp.MustCompile(`AC[0-9a-f]{32}`)
+	// E.164 numbers, except Twilio's magic test numbers.
+	phoneNumberRx = regexp.MustCompile(`\+[1-9][0-9]{6,14}`)
+	// US numbers as formatted in fields like "friendly_name".
+	nationalNumberRx = regexp.MustCompile(`\(([2-9][0-9]{2})\) ([0-9]{3})-([0-9]{4})`)
+)
+
+// fakeNumber returns the fake number in the +1500555 range that replaces
+// number, which should be in E.164 format.
+func fakeNumber(number string) string {
+	if strings.HasPrefix(number, "+1500555") {
+		return number
+	}
+	h := fnv.New32a()
+	io.WriteString(h, number)
+	// Skip +15005550000 through +15005550099, which include the magic
+	// numbers.
+	return fmt.Sprintf("+1500555%04d", 100+h.Sum32()%9900)
+}
+
+// redact removes Account sids, phone numbers and each of secrets from s.
+func redact(s string, secrets []string) string {
+	for _, secret := range secrets {
+		s = strings.Replace(s, secret, "[redacted]", -1)
+	}
+	s = accountSidRx.ReplaceAllString(s, AccountSid)
+	s = phoneNumberRx.ReplaceAllStringFunc(s, fakeNumber)
+	return nationalNumberRx.ReplaceAllStringFunc(s, func(match string) string {
+		m := nationalNumberRx.FindStringSubmatch(match)
+		fake := fakeNumber("+1" + m[1] + m[2] + m[3])
+		return "(" + fake[2:5] + ") " + fake[5:8] + "-" + fake[8:]
+	})
+}
+
+func redactValues(vals url.Values, secrets []string) url.Values {
+	out := make(url.Values, len(vals))
+	for k, vs := range vals {
+		for _, v := range vs {
+			out.Add(k, redact(v, secrets))
+		}
+	}
+	return out
+}
+
+// redactURL returns u with sensitive values removed and its query parameters
+// sorted.
+func redactURL(u *url.URL, secrets []string) string {
+	s := u.Scheme + "://" + u.Host + redact(u.Path, secrets)
+	if u.RawQuery != "" {
+		s += "?" + redactValues(u.Query(), secrets).Encode()
+	}
+	return s
+}
+
+// redactForm returns body, which should be form encoded, with sensitive
+// values removed and its parameters sorted.
+func redactForm(body string, secrets []string) string {
+	if body == "" {
+		return ""
+	}
+	vals, err := url.ParseQuery(body)
+	if err != nil {
+		return redact(body, secrets)
+	}
+	return redactValues(vals, secrets).Encode()
+}
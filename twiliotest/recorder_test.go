@@ -0,0 +1,188 @@
+package twiliotest
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	twilio "github.com/kevinburke/twilio-go"
+)
+
+const realAccountSid = "AC0123456789abcdef0123456789abcdef"
+
+func recorderClient(t *testing.T, rec *Recorder, accountSid, base string) *twilio.Client {
+	t.Helper()
+	client := twilio.NewClient(accountSid, "secret-auth-token", rec.Client())
+	client.Base = base
+	return client
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	t.Parallel()
+	cassette := filepath.Join(t.TempDir(), "messages.jsonl")
+	server := NewServer()
+	server.AccountSid = realAccountSid
+	server.AuthToken = "secret-auth-token"
+
+	rec, err := NewRecorder(cassette, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := recorderClient(t, rec, realAccountSid, server.URL)
+	sent, err := client.Messages.SendMessage(NumberValid, "+14105551234", "hello", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent.AccountSid != realAccountSid || sent.To != "+14105551234" {
+		t.Errorf("recording should not change responses, got %#v", sent)
+	}
+	if _, err := client.Messages.Get(context.Background(), sent.Sid); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	data, err := ioutil.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{realAccountSid, "secret-auth-token", "+14105551234", "(410) 555-1234", "Authorization"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+	if n := strings.Count(string(data), "\n"); n != 2 {
+		t.Errorf("expected 2 recorded requests, got %d", n)
+	}
+
+	rec, err = NewRecorder(cassette, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The server is closed, and the replay uses a different Account; both
+	// are fine since nothing goes over the network.
+	client = recorderClient(t, rec, "AC99999999999999999999999999999999", server.URL)
+	msg, err := client.Messages.SendMessage(NumberValid, "+14105551234", "hello", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Sid != sent.Sid || msg.AccountSid != AccountSid || string(msg.To) != fakeNumber("+14105551234") {
+		t.Errorf("bad replayed message: %#v", msg)
+	}
+	if _, err := client.Messages.Get(context.Background(), sent.Sid); err != nil {
+		t.Fatal(err)
+	}
+	if n := rec.Unused(); n != 0 {
+		t.Errorf("expected every recording to be used, got %d unused", n)
+	}
+	// Each recording is only replayed once.
+	_, err = client.Messages.Get(context.Background(), sent.Sid)
+	if err == nil || !strings.Contains(err.Error(), "no unused recording") {
+		t.Errorf("expected unmatched request error, got %v", err)
+	}
+	if err := rec.Close(); err == nil {
+		t.Error("expected Close to report the unmatched request")
+	}
+}
+
+func TestRecorderUnmatched(t *testing.T) {
+	t.Parallel()
+	cassette := filepath.Join(t.TempDir(), "calls.jsonl")
+	server := NewServer()
+	defer server.Close()
+	rec, err := NewRecorder(cassette, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := twilio.NewClient(AccountSid, AuthToken, rec.Client())
+	client.Base = server.URL
+	if _, err := client.Messages.SendMessage(NumberValid, "+14105551234", "hello", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err = NewRecorder(cassette, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = twilio.NewClient(AccountSid, AuthToken, rec.Client())
+	client.Base = server.URL
+	// Same path, different form body.
+	_, err = client.Messages.SendMessage(NumberValid, "+14105551234", "goodbye", nil)
+	if err == nil || !strings.Contains(err.Error(), "Body=goodbye") {
+		t.Errorf("expected unmatched request error, got %v", err)
+	}
+	if rec.Unused() != 1 {
+		t.Errorf("expected the recording to be unused")
+	}
+	if err := rec.Close(); err == nil {
+		t.Error("expected Close to report the unmatched request")
+	}
+
+	if _, err := NewRecorder(filepath.Join(t.TempDir(), "missing.jsonl"), ModeReplay); err == nil {
+		t.Error("expected error replaying a missing cassette")
+	}
+}
+
+func TestRecorderQueryOrder(t *testing.T) {
+	t.Parallel()
+	a, _ := url.Parse("https://api.twilio.com/2010-04-01/Accounts/" + realAccountSid + "/Messages.json?To=%2B14105551234&PageSize=2")
+	b, _ := url.Parse("https://api.twilio.com/2010-04-01/Accounts/" + AccountSid + "/Messages.json?PageSize=2&To=%2B14105551234")
+	if ra, rb := redactURL(a, nil), redactURL(b, nil); ra != rb {
+		t.Errorf("expected URLs to match after redaction, got %q and %q", ra, rb)
+	}
+}
+
+func TestRecorderMedia(t *testing.T) {
+	t.Parallel()
+	const location = "https://s3-external-1.amazonaws.com/media.twiliocdn.com/" + realAccountSid + "/a1b2c3"
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Location", location)
+		w.WriteHeader(http.StatusFound)
+	}))
+	cassette := filepath.Join(t.TempDir(), "media.jsonl")
+	rec, err := NewRecorder(cassette, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := recorderClient(t, rec, realAccountSid, server.URL)
+	u, err := client.Media.GetURL(context.Background(), "MM89a8c4a6891c53054e9cd604922bfb61", "ME4f366233682e811f63f73220bc07fc34")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.String() != location {
+		t.Errorf("bad media URL: %s", u)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request to the server, got %d", requests)
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	rec, err = NewRecorder(cassette, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Close()
+	client = recorderClient(t, rec, realAccountSid, server.URL)
+	u, err = client.Media.GetURL(context.Background(), "MM89a8c4a6891c53054e9cd604922bfb61", "ME4f366233682e811f63f73220bc07fc34")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strings.Replace(location, realAccountSid, AccountSid, 1); u.String() != want {
+		t.Errorf("bad replayed media URL: got %s, want %s", u, want)
+	}
+}
@@ -0,0 +1,620 @@
+package twiliotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	twilio "github.com/kevinburke/twilio-go"
+)
+
+func newCollections() map[string]*collection {
+	cs := []*collection{
+		{
+			name:      "Messages",
+			key:       "messages",
+			filters:   withFilters(dateFilters("DateSent", "date_sent"), "To", "From"),
+			deletable: true,
+			create:    createMessage,
+			update:    updateMessage,
+		},
+		{
+			name:      "Media",
+			key:       "media_list",
+			nested:    true,
+			filters:   dateFilters("DateCreated", "date_created"),
+			deletable: true,
+		},
+		{
+			name:      "Calls",
+			key:       "calls",
+			filters:   withFilters(dateFilters("StartTime", "start_time"), "To", "From", "Status", "ParentCallSid"),
+			deletable: true,
+			create:    createCall,
+			update:    updateCall,
+		},
+		{
+			name:     "IncomingPhoneNumbers",
+			key:      "incoming_phone_numbers",
+			subLists: map[string]bool{"Local": true, "Mobile": true, "TollFree": true},
+			filters: map[string]filter{
+				"PhoneNumber":  {"phone_number", "~"},
+				"FriendlyName": {"friendly_name", ""},
+			},
+			deletable: true,
+			create:    createIncomingNumber,
+			update:    updateIncomingNumber,
+		},
+		{
+			name:      "Recordings",
+			key:       "recordings",
+			filters:   withFilters(dateFilters("DateCreated", "date_created"), "CallSid", "ConferenceSid"),
+			deletable: true,
+		},
+		{
+			name: "Sims",
+			key:  "sims",
+			v1:   true,
+			filters: map[string]filter{
+				"Status":   {"status", ""},
+				"Iccid":    {"iccid", ""},
+				"RatePlan": {"rate_plan_sid", ""},
+			},
+			update: updateSim,
+		},
+		{
+			name: "Commands",
+			key:  "commands",
+			v1:   true,
+			filters: map[string]filter{
+				"Sim":       {"sim_sid", ""},
+				"Status":    {"status", ""},
+				"Direction": {"direction", ""},
+			},
+			deletable: true,
+			create:    createCommand,
+		},
+		{
+			name: "Alerts",
+			key:  "alerts",
+			v1:   true,
+			filters: map[string]filter{
+				"LogLevel":    {"log_level", ""},
+				"ResourceSid": {"resource_sid", ""},
+				"StartDate":   {"date_generated", ">="},
+				"EndDate":     {"date_generated", "<="},
+			},
+			deletable: true,
+		},
+	}
+	m := make(map[string]*collection, len(cs))
+	for _, c := range cs {
+		m[c.name] = c
+	}
+	return m
+}
+
+// withFilters adds an equality filter for each param to filters.
+func withFilters(filters map[string]filter, params ...string) map[string]filter {
+	for _, param := range params {
+		filters[param] = filter{snake(param), ""}
+	}
+	return filters
+}
+
+// snake converts a parameter name like "SmsFallbackUrl" to the name of the
+// corresponding field, like "sms_fallback_url".
+func snake(param string) string {
+	var b strings.Builder
+	for i, r := range param {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// setParams copies each of params that is present in form to the
+// corresponding field of res.
+func setParams(res resource, form url.Values, params ...string) {
+	for _, param := range params {
+		if vals, ok := form[param]; ok && len(vals) > 0 {
+			res[snake(param)] = vals[0]
+		}
+	}
+}
+
+// nullable returns nil for an empty string, which is encoded as null.
+func nullable(val string) interface{} {
+	if val == "" {
+		return nil
+	}
+	return val
+}
+
+// uri returns the path to a resource in the 2010-04-01 API.
+func (s *Server) uri(parts ...string) string {
+	return "/" + strings.Join(append([]string{twilio.APIVersion, "Accounts", s.AccountSid}, parts...), "/") + ".json"
+}
+
+// numSegments approximates the number of SMS segments needed to send body.
+func numSegments(body string) int {
+	n := len([]rune(body))
+	if n <= 160 {
+		return 1
+	}
+	return (n + 152) / 153
+}
+
+func createMessage(s *Server, form url.Values) (resource, *apiError) {
+	to, from := form.Get("To"), form.Get("From")
+	serviceSid := form.Get("MessagingServiceSid")
+	body, media := form.Get("Body"), form["MediaUrl"]
+	switch {
+	case to == "":
+		return nil, badRequest(21604, "A 'To' phone number is required.")
+	case from == "" && serviceSid == "":
+		return nil, badRequest(21603, "A 'From' phone number is required.")
+	case body == "" && len(media) == 0:
+		return nil, badRequest(21602, "Message body is required.")
+	}
+	if err := messageFromError(from); err != nil {
+		return nil, err
+	}
+	if err := messageToError(to, from); err != nil {
+		return nil, err
+	}
+	prefix := "SM"
+	if len(media) > 0 {
+		prefix = "MM"
+	}
+	sid := s.newSid(prefix)
+	now := formatTime(false, time.Now())
+	status := twilio.StatusQueued
+	if from == "" {
+		status = twilio.StatusAccepted
+	}
+	scheduled := form.Get("ScheduleType") == "fixed"
+	if scheduled {
+		if serviceSid == "" {
+			return nil, badRequest(35111, "MessagingServiceSid is required to schedule a message.")
+		}
+		status = twilio.StatusScheduled
+	}
+	res := resource{
+		"account_sid":           s.AccountSid,
+		"api_version":           twilio.APIVersion,
+		"body":                  body,
+		"date_created":          now,
+		"date_sent":             nil,
+		"date_updated":          now,
+		"direction":             twilio.DirectionOutboundAPI,
+		"error_code":            nil,
+		"error_message":         nil,
+		"from":                  nullable(from),
+		"messaging_service_sid": nullable(serviceSid),
+		"num_media":             strconv.Itoa(len(media)),
+		"num_segments":          strconv.Itoa(numSegments(body)),
+		"price":                 nil,
+		"price_unit":            "USD",
+		"sid":                   sid,
+		"status":                status,
+		"subresource_uris":      map[string]string{"media": s.uri("Messages", sid, "Media")},
+		"to":                    to,
+		"uri":                   s.uri("Messages", sid),
+	}
+	for _, mediaURL := range media {
+		s.addMedia(sid, mediaURL)
+	}
+	if !scheduled {
+		s.deliverMessage(res, form.Get("StatusCallback"))
+	}
+	return res, nil
+}
+
+// addMedia stores a Media resource for mediaURL, sent with the message
+// messageSid. s.mu must be held.
+func (s *Server) addMedia(messageSid string, mediaURL string) {
+	contentType := mime.TypeByExtension(path.Ext(mediaURL))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	sid := s.newSid("ME")
+	now := formatTime(false, time.Now())
+	c := s.collections["Media"]
+	c.items = append(c.items, resource{
+		"account_sid":  s.AccountSid,
+		"content_type": contentType,
+		"date_created": now,
+		"date_updated": now,
+		"parent_sid":   messageSid,
+		"sid":          sid,
+		"uri":          s.uri("Messages", messageSid, "Media", sid),
+	})
+}
+
+func updateMessage(s *Server, res resource, form url.Values) *apiError {
+	if _, ok := form["Body"]; ok {
+		if form.Get("Body") != "" {
+			return badRequest(20001, "Body can only be updated to an empty string, to redact the Message.")
+		}
+		res["body"] = ""
+	}
+	if _, ok := form["Status"]; ok {
+		if twilio.Status(form.Get("Status")) != twilio.StatusCanceled {
+			return badRequest(20001, "Status can only be updated to canceled.")
+		}
+		if twilio.Status(res.str("status")) != twilio.StatusScheduled {
+			return badRequest(20001, "Only scheduled messages can be canceled.")
+		}
+		res["status"] = twilio.StatusCanceled
+	}
+	return nil
+}
+
+func createCall(s *Server, form url.Values) (resource, *apiError) {
+	to, from := form.Get("To"), form.Get("From")
+	switch {
+	case to == "":
+		return nil, badRequest(21201, "No 'To' number is specified")
+	case from == "":
+		return nil, badRequest(21213, "No 'From' number is specified")
+	case form.Get("Url") == "" && form.Get("Twiml") == "" && form.Get("ApplicationSid") == "":
+		return nil, badRequest(21205, "Url parameter is required.")
+	}
+	if err := callFromError(from); err != nil {
+		return nil, err
+	}
+	if err := callToError(to); err != nil {
+		return nil, err
+	}
+	var numberSid interface{}
+	for _, number := range s.collections["IncomingPhoneNumbers"].items {
+		if number.str("phone_number") == from {
+			numberSid = number.str("sid")
+		}
+	}
+	sid := s.newSid("CA")
+	now := formatTime(false, time.Now())
+	res := resource{
+		"account_sid":      s.AccountSid,
+		"annotation":       nil,
+		"answered_by":      nil,
+		"api_version":      twilio.APIVersion,
+		"caller_name":      nil,
+		"date_created":     now,
+		"date_updated":     now,
+		"direction":        twilio.DirectionOutboundAPI,
+		"duration":         nil,
+		"end_time":         nil,
+		"forwarded_from":   nil,
+		"from":             from,
+		"from_formatted":   twilio.PhoneNumber(from).Local(),
+		"group_sid":        nil,
+		"parent_call_sid":  nil,
+		"phone_number_sid": numberSid,
+		"price":            nil,
+		"price_unit":       "USD",
+		"sid":              sid,
+		"start_time":       nil,
+		"status":           twilio.StatusQueued,
+		"subresource_uris": map[string]string{
+			"events":        s.uri("Calls", sid, "Events"),
+			"feedback":      s.uri("Calls", sid, "Feedback"),
+			"notifications": s.uri("Calls", sid, "Notifications"),
+			"recordings":    s.uri("Calls", sid, "Recordings"),
+		},
+		"to":           to,
+		"to_formatted": twilio.PhoneNumber(to).Local(),
+		"uri":          s.uri("Calls", sid),
+	}
+	s.connectCall(res, form)
+	return res, nil
+}
+
+func updateCall(s *Server, res resource, form url.Values) *apiError {
+	status := twilio.Status(res.str("status"))
+	ended := status != twilio.StatusQueued && status != twilio.StatusRinging && status != twilio.StatusInProgress
+	if form.Get("Url") != "" || form.Get("Twiml") != "" {
+		if ended {
+			return badRequest(21220, "Call is not in-progress. Cannot redirect.")
+		}
+	}
+	switch twilio.Status(form.Get("Status")) {
+	case "":
+	case twilio.StatusCanceled:
+		// Canceling a call that has been answered has no effect.
+		if status == twilio.StatusQueued || status == twilio.StatusRinging {
+			s.endCall(res, twilio.StatusCanceled)
+		}
+	case twilio.StatusCompleted:
+		if !ended {
+			s.endCall(res, twilio.StatusCompleted)
+		}
+	default:
+		return badRequest(20001, "Status must be one of canceled or completed.")
+	}
+	return nil
+}
+
+var incomingNumberParams = []string{
+	"FriendlyName",
+	"SmsApplicationSid", "SmsFallbackMethod", "SmsFallbackUrl", "SmsMethod", "SmsUrl",
+	"StatusCallback", "StatusCallbackMethod",
+	"VoiceApplicationSid", "VoiceFallbackMethod", "VoiceFallbackUrl", "VoiceMethod", "VoiceUrl",
+}
+
+func createIncomingNumber(s *Server, form url.Values) (resource, *apiError) {
+	phoneNumber, areaCode := form.Get("PhoneNumber"), form.Get("AreaCode")
+	if phoneNumber == "" && areaCode == "" {
+		return nil, missingParameter("PhoneNumber or AreaCode")
+	}
+	if err := purchaseError(phoneNumber, areaCode); err != nil {
+		return nil, err
+	}
+	sid := s.newSid("PN")
+	if phoneNumber == "" {
+		phoneNumber = fmt.Sprintf("+1%s555%04d", areaCode, s.lastID%10000)
+	}
+	now := formatTime(false, time.Now())
+	res := resource{
+		"account_sid":            s.AccountSid,
+		"address_requirements":   "none",
+		"api_version":            twilio.APIVersion,
+		"beta":                   false,
+		"capabilities":           map[string]bool{"mms": true, "sms": true, "voice": true},
+		"date_created":           now,
+		"date_updated":           now,
+		"emergency_address_sid":  nil,
+		"emergency_status":       "Inactive",
+		"friendly_name":          twilio.PhoneNumber(phoneNumber).Local(),
+		"phone_number":           phoneNumber,
+		"sid":                    sid,
+		"sms_application_sid":    "",
+		"sms_fallback_method":    "POST",
+		"sms_fallback_url":       "",
+		"sms_method":             "POST",
+		"sms_url":                "",
+		"status_callback":        "",
+		"status_callback_method": "POST",
+		"trunk_sid":              nil,
+		"uri":                    s.uri("IncomingPhoneNumbers", sid),
+		"voice_application_sid":  "",
+		"voice_caller_id_lookup": false,
+		"voice_fallback_method":  "POST",
+		"voice_fallback_url":     "",
+		"voice_method":           "POST",
+		"voice_url":              "",
+	}
+	setParams(res, form, incomingNumberParams...)
+	return res, nil
+}
+
+func updateIncomingNumber(s *Server, res resource, form url.Values) *apiError {
+	setParams(res, form, incomingNumberParams...)
+	if val := form.Get("VoiceCallerIdLookup"); val != "" {
+		res["voice_caller_id_lookup"] = val == "true"
+	}
+	return nil
+}
+
+func updateSim(s *Server, res resource, form url.Values) *apiError {
+	setParams(res, form,
+		"UniqueName", "FriendlyName", "Status",
+		"CommandsCallbackMethod", "CommandsCallbackUrl",
+		"SmsFallbackMethod", "SmsFallbackUrl", "SmsMethod", "SmsUrl",
+		"VoiceFallbackMethod", "VoiceFallbackUrl", "VoiceMethod", "VoiceUrl",
+	)
+	if ratePlan := form.Get("RatePlan"); ratePlan != "" {
+		res["rate_plan_sid"] = ratePlan
+	}
+	return nil
+}
+
+func createCommand(s *Server, form url.Values) (resource, *apiError) {
+	simParam, command := form.Get("Sim"), form.Get("Command")
+	switch {
+	case simParam == "":
+		return nil, missingParameter("Sim")
+	case command == "":
+		return nil, missingParameter("Command")
+	}
+	var sim resource
+	for _, res := range s.collections["Sims"].items {
+		if res.str("sid") == simParam || res.str("unique_name") == simParam {
+			sim = res
+		}
+	}
+	if sim == nil {
+		return nil, newError(http.StatusNotFound, twilio.CodeNotFound, "The requested resource /Sims/%s was not found", simParam)
+	}
+	mode := form.Get("CommandMode")
+	if mode == "" {
+		mode = "text"
+	}
+	sid := s.newSid("DC")
+	now := formatTime(true, time.Now())
+	res := resource{
+		"account_sid":                s.AccountSid,
+		"command":                    command,
+		"command_mode":               mode,
+		"date_created":               now,
+		"date_updated":               now,
+		"delivery_receipt_requested": true,
+		"direction":                  "to_sim",
+		"sid":                        sid,
+		"sim_sid":                    sim.str("sid"),
+		"status":                     twilio.StatusQueued,
+		"transport":                  "sms",
+		"url":                        s.URL + "/v1/Commands/" + sid,
+	}
+	callbackURL := form.Get("CallbackUrl")
+	if callbackURL == "" {
+		callbackURL = sim.str("commands_callback_url")
+	}
+	s.deliverCommand(res, sim.str("unique_name"), callbackURL)
+	return res, nil
+}
+
+// add stores res in the named collection and decodes it into v.
+func (s *Server) add(name string, res resource, v interface{}) {
+	s.collections[name].items = append(s.collections[name].items, res)
+	decode(res, v)
+}
+
+// decode converts resources to a twilio-go type, using the same JSON the
+// Server would serve for them. s.mu must be held.
+func decode(res interface{}, v interface{}) {
+	b, err := json.Marshal(res)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		panic(fmt.Sprintf("twiliotest: decoding %s: %v", b, err))
+	}
+}
+
+// AddSim adds an active Sim with the given unique name, so it can be used to
+// send Commands, and returns it.
+func (s *Server) AddSim(uniqueName string) *twilio.Sim {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sid := s.newSid("DE")
+	now := formatTime(true, time.Now())
+	sim := new(twilio.Sim)
+	s.add("Sims", resource{
+		"account_sid":              s.AccountSid,
+		"commands_callback_method": "POST",
+		"commands_callback_url":    nil,
+		"date_created":             now,
+		"date_updated":             now,
+		"friendly_name":            nil,
+		"iccid":                    fmt.Sprintf("8901%016d", s.lastID),
+		"links": map[string]string{
+			"rate_plan":     s.URL + "/v1/RatePlans/WP00000000000000000000000000000001",
+			"usage_records": s.URL + "/v1/Sims/" + sid + "/UsageRecords",
+		},
+		"rate_plan_sid":         "WP00000000000000000000000000000001",
+		"sid":                   sid,
+		"sms_fallback_method":   nil,
+		"sms_fallback_url":      nil,
+		"sms_method":            nil,
+		"sms_url":               nil,
+		"status":                twilio.StatusActive,
+		"unique_name":           uniqueName,
+		"url":                   s.URL + "/v1/Sims/" + sid,
+		"voice_fallback_method": nil,
+		"voice_fallback_url":    nil,
+		"voice_method":          nil,
+		"voice_url":             nil,
+	}, sim)
+	return sim
+}
+
+// AddRecording adds a completed Recording of the Call with the given sid, and
+// returns it.
+func (s *Server) AddRecording(callSid string, duration time.Duration) *twilio.Recording {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sid := s.newSid("RE")
+	now := formatTime(false, time.Now())
+	rec := new(twilio.Recording)
+	s.add("Recordings", resource{
+		"account_sid":        s.AccountSid,
+		"api_version":        twilio.APIVersion,
+		"call_sid":           callSid,
+		"channels":           1,
+		"conference_sid":     nil,
+		"date_created":       now,
+		"date_updated":       now,
+		"duration":           strconv.Itoa(int(duration / time.Second)),
+		"encryption_details": nil,
+		"error_code":         nil,
+		"price":              "-0.00250",
+		"price_unit":         "USD",
+		"sid":                sid,
+		"source":             "OutboundAPI",
+		"start_time":         now,
+		"status":             twilio.StatusCompleted,
+		"uri":                s.uri("Recordings", sid),
+	}, rec)
+	return rec
+}
+
+// AddAlert adds an Alert with the given error code, level and text, and
+// returns it.
+func (s *Server) AddAlert(code twilio.Code, level twilio.LogLevel, text string) *twilio.Alert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sid := s.newSid("NO")
+	now := formatTime(true, time.Now())
+	alert := new(twilio.Alert)
+	s.add("Alerts", resource{
+		"account_sid":    s.AccountSid,
+		"alert_text":     text,
+		"api_version":    twilio.APIVersion,
+		"date_created":   now,
+		"date_generated": now,
+		"date_updated":   now,
+		"error_code":     strconv.Itoa(int(code)),
+		"log_level":      level,
+		"more_info":      "https://www.twilio.com/docs/errors/" + strconv.Itoa(int(code)),
+		"request_method": "",
+		"request_url":    "",
+		"resource_sid":   "",
+		"service_sid":    nil,
+		"sid":            sid,
+		"url":            s.URL + "/v1/Alerts/" + sid,
+	}, alert)
+	return alert
+}
+
+// Messages returns every Message stored in s, oldest first.
+func (s *Server) Messages() []*twilio.Message {
+	var msgs []*twilio.Message
+	s.decodeAll("Messages", &msgs)
+	return msgs
+}
+
+// Calls returns every Call stored in s, oldest first.
+func (s *Server) Calls() []*twilio.Call {
+	var calls []*twilio.Call
+	s.decodeAll("Calls", &calls)
+	return calls
+}
+
+// IncomingPhoneNumbers returns every IncomingPhoneNumber stored in s, oldest
+// first.
+func (s *Server) IncomingPhoneNumbers() []*twilio.IncomingPhoneNumber {
+	var numbers []*twilio.IncomingPhoneNumber
+	s.decodeAll("IncomingPhoneNumbers", &numbers)
+	return numbers
+}
+
+// Commands returns every Command stored in s, oldest first.
+func (s *Server) Commands() []*twilio.Command {
+	var cmds []*twilio.Command
+	s.decodeAll("Commands", &cmds)
+	return cmds
+}
+
+func (s *Server) decodeAll(name string, v interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := s.collections[name].items
+	if items == nil {
+		items = []resource{}
+	}
+	decode(items, v)
+}
@@ -0,0 +1,495 @@
+// Package twiliotest provides an in-memory fake of the Twilio API, for testing
+// code that uses twilio-go without making network requests or spending money.
+//
+// A Server stores the Messages (and their Media), Calls, IncomingPhoneNumbers,
+// Recordings, Sims, Commands and Alerts it has created or been given, serves
+// them with the
+// same JSON and paging as the Twilio API, and returns Twilio's errors for the
+// magic test phone numbers (see NumberValid and friends). Client returns a
+// *twilio.Client that sends all of its requests to the Server:
+//
+//	server := twiliotest.NewServer()
+//	defer server.Close()
+//	client := server.Client()
+//	msg, err := client.Messages.SendMessage(twiliotest.NumberValid, "+14105551234", "hello", nil)
+//
+// Messages, Calls and Commands move through their statuses in the background,
+// and the StatusCallback URL for each is called with a signed request, just as
+// Twilio would. Call Wait before asserting on the final status of a resource
+// or on the callbacks your server received.
+package twiliotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	twilio "github.com/kevinburke/twilio-go"
+)
+
+// The credentials a Server accepts, unless AccountSid or AuthToken is changed
+// before the first request.
+const (
+	AccountSid = "AC00000000000000000000000000000000"
+	AuthToken  = "twiliotest-auth-token"
+)
+
+const defaultPageSize = 50
+const maxPageSize = 1000
+
+// A Server is a fake Twilio API. Create one with NewServer.
+type Server struct {
+	// URL is the base URL of the Server, of the form http://127.0.0.1:port.
+	URL string
+	// AccountSid and AuthToken are the credentials the Server accepts, and
+	// the values used to sign status callbacks.
+	AccountSid string
+	AuthToken  string
+
+	srv *httptest.Server
+
+	mu          sync.Mutex
+	lastID      int
+	collections map[string]*collection
+	calls       map[string]*callProgress
+	pending     sync.WaitGroup
+	callbackErr error
+}
+
+// A resource is a single API resource, stored as the JSON object the Twilio
+// API would return for it.
+type resource map[string]interface{}
+
+// str returns the value of the field as a string, or "" if it is null.
+func (r resource) str(key string) string {
+	if v := r[key]; v != nil {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// NewServer starts and returns a new Server. Call Close when you are done
+// with it.
+func NewServer() *Server {
+	s := &Server{
+		AccountSid:  AccountSid,
+		AuthToken:   AuthToken,
+		collections: newCollections(),
+	}
+	s.srv = httptest.NewServer(s)
+	s.URL = s.srv.URL
+	return s
+}
+
+// Client returns a *twilio.Client, and clients for each of the other Twilio
+// products, configured to make requests to s.
+func (s *Server) Client() *twilio.Client {
+	return twilio.NewClientWithOptions(s.AccountSid, s.AuthToken, s.Options()...)
+}
+
+// Options returns the options that point a Client at s, for combining with
+// your own options:
+//
+//	opts := append(server.Options(), twilio.WithUserAgentSuffix("myapp/1.2"))
+//	client := twilio.NewClientWithOptions(twiliotest.AccountSid, twiliotest.AuthToken, opts...)
+func (s *Server) Options() []twilio.Option {
+	opts := []twilio.Option{twilio.WithHTTPClient(s.srv.Client())}
+	for _, p := range []twilio.Product{
+		twilio.ProductAPI, twilio.ProductMonitor, twilio.ProductPricing,
+		twilio.ProductFax, twilio.ProductWireless, twilio.ProductNotify,
+		twilio.ProductLookup, twilio.ProductVerify, twilio.ProductVideo,
+		twilio.ProductTaskRouter, twilio.ProductInsights, twilio.ProductSuperSim,
+		twilio.ProductMessaging,
+	} {
+		opts = append(opts, twilio.WithBaseURL(p, s.URL))
+	}
+	return opts
+}
+
+// Wait blocks until every status update and status callback that has been
+// started so far has finished. It returns the first error encountered
+// delivering a status callback, including a non-2xx response.
+func (s *Server) Wait() error {
+	s.pending.Wait()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.callbackErr
+}
+
+// Close waits for pending status callbacks, then shuts down the Server.
+func (s *Server) Close() {
+	s.pending.Wait()
+	s.srv.Close()
+}
+
+// newSid returns a new, unique sid with the given prefix. s.mu must be held.
+func (s *Server) newSid(prefix string) string {
+	s.lastID++
+	return fmt.Sprintf("%s%032x", prefix, s.lastID)
+}
+
+// apiError is an error in the format returned by the Twilio API.
+type apiError struct {
+	Code     twilio.Code `json:"code"`
+	Message  string      `json:"message"`
+	MoreInfo string      `json:"more_info"`
+	Status   int         `json:"status"`
+}
+
+func newError(status int, code twilio.Code, format string, args ...interface{}) *apiError {
+	return &apiError{
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		MoreInfo: "https://www.twilio.com/docs/errors/" + strconv.Itoa(int(code)),
+		Status:   status,
+	}
+}
+
+func notFound(r *http.Request) *apiError {
+	return newError(http.StatusNotFound, twilio.CodeNotFound, "The requested resource %s was not found", r.URL.Path)
+}
+
+func missingParameter(name string) *apiError {
+	return newError(http.StatusBadRequest, 20001, "Missing required parameter %s in the post body", name)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	writeJSON(w, err.Status, err)
+}
+
+// ServeHTTP implements the subset of the Twilio API described in the package
+// documentation.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, pass, ok := r.BasicAuth(); !ok || user != s.AccountSid || pass != s.AuthToken {
+		writeError(w, newError(http.StatusUnauthorized, 20003, "Authenticate"))
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeError(w, newError(http.StatusBadRequest, 20001, "Invalid request body: %v", err))
+		return
+	}
+	var segments []string
+	var v1 bool
+	accountPrefix := "/" + twilio.APIVersion + "/Accounts/" + s.AccountSid + "/"
+	switch {
+	case strings.HasPrefix(r.URL.Path, accountPrefix):
+		segments = strings.Split(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, accountPrefix), ".json"), "/")
+	case strings.HasPrefix(r.URL.Path, "/v1/"):
+		segments = strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/"), "/")
+		v1 = true
+	default:
+		writeError(w, notFound(r))
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Media is served at Messages/{MessageSid}/Media.
+	var parentSid string
+	if !v1 && len(segments) >= 3 && segments[0] == "Messages" && segments[2] == "Media" {
+		if s.collections["Messages"].get(segments[1]) == nil {
+			writeError(w, notFound(r))
+			return
+		}
+		parentSid = segments[1]
+		segments = segments[2:]
+	}
+	c, ok := s.collections[segments[0]]
+	if !ok || c.v1 != v1 || c.nested != (parentSid != "") {
+		writeError(w, notFound(r))
+		return
+	}
+	var sid string
+	switch len(segments) {
+	case 1:
+	case 2:
+		sid = segments[1]
+		// IncomingPhoneNumbers/Local and IncomingPhoneNumbers/TollFree
+		// are lists, not instances.
+		if c.subLists[sid] {
+			sid = ""
+		}
+	default:
+		writeError(w, notFound(r))
+		return
+	}
+
+	switch {
+	case sid == "" && r.Method == "GET":
+		writeJSON(w, http.StatusOK, s.list(c, r, parentSid))
+	case sid == "" && r.Method == "POST":
+		if c.create == nil {
+			writeError(w, newError(http.StatusMethodNotAllowed, 20004, "Method not allowed"))
+			return
+		}
+		res, err := c.create(s, r.PostForm)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		c.items = append(c.items, res)
+		writeJSON(w, http.StatusCreated, res)
+	case sid != "":
+		res := c.get(sid)
+		if res == nil || res.str("parent_sid") != parentSid {
+			writeError(w, notFound(r))
+			return
+		}
+		switch r.Method {
+		case "GET":
+			writeJSON(w, http.StatusOK, res)
+		case "POST":
+			if c.update == nil {
+				writeError(w, newError(http.StatusMethodNotAllowed, 20004, "Method not allowed"))
+				return
+			}
+			if err := c.update(s, res, r.PostForm); err != nil {
+				writeError(w, err)
+				return
+			}
+			res["date_updated"] = c.now()
+			writeJSON(w, http.StatusOK, res)
+		case "DELETE":
+			if !c.deletable {
+				writeError(w, newError(http.StatusMethodNotAllowed, 20004, "Method not allowed"))
+				return
+			}
+			c.remove(sid)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, newError(http.StatusMethodNotAllowed, 20004, "Method not allowed"))
+		}
+	default:
+		writeError(w, newError(http.StatusMethodNotAllowed, 20004, "Method not allowed"))
+	}
+}
+
+// list returns a page of the resources in c that belong to parentSid and match
+// the filters in r, in the format used by the API version of c. s.mu must be
+// held.
+func (s *Server) list(c *collection, r *http.Request, parentSid string) map[string]interface{} {
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("Page"))
+	if page < 0 {
+		page = 0
+	}
+	pageSize, _ := strconv.Atoi(query.Get("PageSize"))
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	// Newest resources first, as in the API.
+	matches := make([]resource, 0)
+	for i := len(c.items) - 1; i >= 0; i-- {
+		if c.items[i].str("parent_sid") == parentSid && c.matches(c.items[i], query) {
+			matches = append(matches, c.items[i])
+		}
+	}
+	start := page * pageSize
+	if start > len(matches) {
+		start = len(matches)
+	}
+	end := start + pageSize
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	pageURI := func(n int) string {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("Page", strconv.Itoa(n))
+		q.Set("PageSize", strconv.Itoa(pageSize))
+		return r.URL.Path + "?" + q.Encode()
+	}
+	var next, prev interface{}
+	if end < len(matches) {
+		next = pageURI(page + 1)
+	}
+	if page > 0 {
+		prev = pageURI(page - 1)
+	}
+
+	body := map[string]interface{}{
+		c.key: matches[start:end],
+	}
+	if c.v1 {
+		if next != nil {
+			next = s.URL + next.(string)
+		}
+		if prev != nil {
+			prev = s.URL + prev.(string)
+		}
+		body["meta"] = map[string]interface{}{
+			"first_page_url":    s.URL + pageURI(0),
+			"key":               c.key,
+			"next_page_url":     next,
+			"page":              page,
+			"page_size":         pageSize,
+			"previous_page_url": prev,
+			"url":               s.URL + pageURI(page),
+		}
+		return body
+	}
+	body["first_page_uri"] = pageURI(0)
+	body["next_page_uri"] = next
+	body["previous_page_uri"] = prev
+	body["page"] = page
+	body["page_size"] = pageSize
+	body["start"] = start
+	body["end"] = end - 1
+	if end == start {
+		body["end"] = start
+	}
+	body["uri"] = pageURI(page)
+	return body
+}
+
+// A collection is a list of resources of one type, like Messages.
+type collection struct {
+	// name is the path segment for the collection, like "Messages".
+	name string
+	// key is the name of the list of resources in a page, like "messages".
+	key string
+	// v1 collections are served under /v1 and use "meta" paging; others are
+	// served under /2010-04-01/Accounts/{AccountSid}.
+	v1 bool
+	// nested collections are only served under a parent resource, like
+	// Messages/{MessageSid}/Media, and each item has a parent_sid.
+	nested    bool
+	subLists  map[string]bool
+	filters   map[string]filter
+	deletable bool
+	create    func(s *Server, form url.Values) (resource, *apiError)
+	update    func(s *Server, res resource, form url.Values) *apiError
+	items     []resource
+}
+
+func (c *collection) get(sid string) resource {
+	for _, res := range c.items {
+		if res.str("sid") == sid {
+			return res
+		}
+	}
+	return nil
+}
+
+func (c *collection) remove(sid string) {
+	for i, res := range c.items {
+		if res.str("sid") == sid {
+			c.items = append(c.items[:i], c.items[i+1:]...)
+			return
+		}
+	}
+}
+
+// now returns the current time in the format used by c's API version.
+func (c *collection) now() string {
+	return formatTime(c.v1, time.Now())
+}
+
+func formatTime(v1 bool, t time.Time) string {
+	t = t.UTC().Truncate(time.Second)
+	if v1 {
+		return t.Format(time.RFC3339)
+	}
+	return t.Format(twilio.TimeLayout)
+}
+
+func parseTime(val string) (time.Time, bool) {
+	for _, layout := range []string{twilio.TimeLayout, time.RFC3339, twilio.APISearchLayout} {
+		if t, err := time.Parse(layout, val); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// A filter restricts a list to resources whose field matches a query
+// parameter. If op is empty the values must be equal, and if it is "~" the
+// field must contain the parameter; otherwise the field is a date, compared
+// to the parameter with op.
+type filter struct {
+	field string
+	op    string
+}
+
+// dateFilters returns filters for the date comparisons the API supports for
+// param, like "DateSent>" and "DateSent<=".
+func dateFilters(param string, field string) map[string]filter {
+	return map[string]filter{
+		param:        {field, "="},
+		param + ">":  {field, ">="},
+		param + ">=": {field, ">="},
+		param + "<":  {field, "<"},
+		param + "<=": {field, "<="},
+	}
+}
+
+func (c *collection) matches(res resource, query url.Values) bool {
+	for param := range query {
+		f, ok := c.filters[param]
+		if !ok {
+			continue
+		}
+		val := query.Get(param)
+		switch f.op {
+		case "":
+			if fmt.Sprint(res[f.field]) != val {
+				return false
+			}
+			continue
+		case "~":
+			if !strings.Contains(fmt.Sprint(res[f.field]), val) {
+				return false
+			}
+			continue
+		}
+		got, ok := parseTime(res.str(f.field))
+		if !ok {
+			return false
+		}
+		want, ok := parseTime(val)
+		if !ok {
+			continue
+		}
+		// A date without a time matches the whole day.
+		if len(val) == len(twilio.APISearchLayout) {
+			got = got.UTC().Truncate(24 * time.Hour)
+		}
+		switch f.op {
+		case "=":
+			if !got.Equal(want) {
+				return false
+			}
+		case ">=":
+			if got.Before(want) {
+				return false
+			}
+		case "<":
+			if !got.Before(want) {
+				return false
+			}
+		case "<=":
+			if got.After(want) {
+				return false
+			}
+		}
+	}
+	return true
+}
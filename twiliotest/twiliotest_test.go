@@ -0,0 +1,330 @@
+package twiliotest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	twilio "github.com/kevinburke/twilio-go"
+)
+
+// callbackRecorder is a webhook server that records the value of one
+// parameter from each validated request.
+type callbackRecorder struct {
+	*httptest.Server
+	mu     sync.Mutex
+	values []string
+}
+
+func newCallbackRecorder(param string) *callbackRecorder {
+	rec := new(callbackRecorder)
+	v := twilio.NewRequestValidator(AuthToken)
+	rec.Server = httptest.NewServer(v.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.mu.Lock()
+		rec.values = append(rec.values, r.PostForm.Get(param))
+		rec.mu.Unlock()
+	})))
+	return rec
+}
+
+func TestMessageStatusCallback(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	rec := newCallbackRecorder("MessageStatus")
+	defer rec.Close()
+
+	data := url.Values{}
+	data.Set("From", NumberValid)
+	data.Set("To", "+14105551234")
+	data.Set("Body", "hello")
+	data.Set("StatusCallback", rec.URL+"/status")
+	msg, err := server.Client().Messages.Create(context.Background(), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Status != twilio.StatusQueued || msg.Body != "hello" || msg.NumSegments != 1 {
+		t.Errorf("bad message: %#v", msg)
+	}
+	if err := server.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"sent", "delivered"}; !reflect.DeepEqual(rec.values, want) {
+		t.Errorf("callback statuses: got %v, want %v", rec.values, want)
+	}
+	msg, err = server.Client().Messages.Get(context.Background(), msg.Sid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Status != twilio.StatusDelivered || !msg.DateSent.Valid || msg.FriendlyPrice() != "$0.0079" {
+		t.Errorf("bad message: %#v", msg)
+	}
+}
+
+func TestScheduledMessage(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+	msg, err := client.Messages.CreateWithParams(ctx, &twilio.MessageParams{
+		To:                  "+14105551234",
+		MessagingServiceSid: "MG123",
+		Body:                "hello later",
+		SendAt:              time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Status != twilio.StatusScheduled {
+		t.Errorf("expected StatusScheduled, got %q", msg.Status)
+	}
+	msg, err = client.Messages.CancelScheduled(ctx, msg.Sid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Status != twilio.StatusCanceled {
+		t.Errorf("expected StatusCanceled, got %q", msg.Status)
+	}
+	if _, err := client.Messages.CancelScheduled(ctx, msg.Sid); err == nil {
+		t.Error("expected an error canceling a canceled message")
+	}
+}
+
+func TestCallLifecycle(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	rec := newCallbackRecorder("CallStatus")
+	defer rec.Close()
+	client := server.Client()
+
+	data := url.Values{}
+	data.Set("From", NumberValid)
+	data.Set("To", "+14105551234")
+	data.Set("Url", "https://example.com/voice.xml")
+	data.Set("StatusCallback", rec.URL)
+	data.Add("StatusCallbackEvent", "initiated ringing")
+	data.Add("StatusCallbackEvent", "answered")
+	data.Add("StatusCallbackEvent", "completed")
+	call, err := client.Calls.Create(context.Background(), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	call, err = client.Calls.Get(context.Background(), call.Sid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Status != twilio.StatusInProgress || !call.StartTime.Valid {
+		t.Errorf("expected call to be in progress, got %#v", call)
+	}
+	call, err = client.Calls.Hangup(call.Sid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Status != twilio.StatusCompleted || !call.EndTime.Valid {
+		t.Errorf("expected call to be completed, got %#v", call)
+	}
+	if err := server.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"initiated", "ringing", "in-progress", "completed"}; !reflect.DeepEqual(rec.values, want) {
+		t.Errorf("callback statuses: got %v, want %v", rec.values, want)
+	}
+	if _, err := client.Calls.Redirect(call.Sid, &url.URL{Scheme: "https", Host: "example.com"}); err == nil {
+		t.Error("expected redirecting a completed call to fail")
+	}
+}
+
+func TestMagicNumbers(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	_, err := client.Messages.SendMessage(NumberValid, NumberBlocked, "hi", nil)
+	if !twilio.IsUnsubscribed(err) {
+		t.Errorf("expected unsubscribed error, got %v", err)
+	}
+	_, err = client.Messages.SendMessage(NumberInvalid, "+14105551234", "hi", nil)
+	if !twilio.IsInvalidPhoneNumber(err) {
+		t.Errorf("expected invalid phone number error, got %v", err)
+	}
+	_, err = client.Calls.MakeCall(NumberValid, NumberUnroutable, &url.URL{Scheme: "https", Host: "example.com"})
+	if terr, ok := twilio.AsError(err); !ok || terr.Code != 21214 {
+		t.Errorf("expected 21214 error, got %v", err)
+	}
+	_, err = client.IncomingNumbers.BuyNumber(NumberUnavailable)
+	if terr, ok := twilio.AsError(err); !ok || terr.Code != 21422 {
+		t.Errorf("expected 21422 error, got %v", err)
+	}
+	number, err := client.IncomingNumbers.BuyNumber(NumberValid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if number.PhoneNumber != NumberValid || number.FriendlyName != "(500) 555-0006" {
+		t.Errorf("bad number: %#v", number)
+	}
+	if msgs := server.Messages(); len(msgs) != 0 {
+		t.Errorf("expected no messages to be stored, got %d", len(msgs))
+	}
+}
+
+func TestPaging(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	for i := 0; i < 5; i++ {
+		if _, err := client.Messages.SendMessage(NumberValid, "+14105551234", "hi", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.Messages.SendMessage(NumberValid, "+14105556789", "hi", nil); err != nil {
+		t.Fatal(err)
+	}
+	data := url.Values{}
+	data.Set("To", "+14105551234")
+	data.Set("PageSize", "2")
+	iter := client.Messages.GetPageIterator(data)
+	var pages, count int
+	for {
+		page, err := iter.Next(context.Background())
+		if err == twilio.NoMoreResults {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages++
+		count += len(page.Messages)
+	}
+	if pages != 3 || count != 5 {
+		t.Errorf("expected 5 messages in 3 pages, got %d in %d", count, pages)
+	}
+
+	for i := 0; i < 3; i++ {
+		server.AddAlert(twilio.CodeHTTPRetrievalFailure, twilio.LogLevelError, "msg=HTTP+retrieval+failure")
+	}
+	server.AddAlert(twilio.CodeHTTPRetrievalFailure, twilio.LogLevelWarning, "msg=HTTP+retrieval+failure")
+	data = url.Values{}
+	data.Set("LogLevel", "error")
+	data.Set("PageSize", "2")
+	alerts := client.Monitor.Alerts.GetPageIterator(data)
+	pages, count = 0, 0
+	for {
+		page, err := alerts.Next(context.Background())
+		if err == twilio.NoMoreResults {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages++
+		count += len(page.Alerts)
+	}
+	if pages != 2 || count != 3 {
+		t.Errorf("expected 3 alerts in 2 pages, got %d in %d", count, pages)
+	}
+}
+
+func TestCommands(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	rec := newCallbackRecorder("CommandStatus")
+	defer rec.Close()
+	client := server.Client()
+
+	sim := server.AddSim("tracker-1")
+	if _, err := client.Wireless.Sims.Update(context.Background(), sim.Sid, url.Values{"CommandsCallbackUrl": {rec.URL}}); err != nil {
+		t.Fatal(err)
+	}
+	cmd, err := client.Wireless.Commands.Send(context.Background(), "tracker-1", "ping")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd.SimSid != sim.Sid || cmd.Command != "ping" {
+		t.Errorf("bad command: %#v", cmd)
+	}
+	if err := server.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"sent", "delivered"}; !reflect.DeepEqual(rec.values, want) {
+		t.Errorf("callback statuses: got %v, want %v", rec.values, want)
+	}
+	if cmds := server.Commands(); len(cmds) != 1 || cmds[0].Status != twilio.StatusDelivered {
+		t.Errorf("bad stored commands: %#v", cmds)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	_, err := server.Client().Messages.Get(context.Background(), "SM123")
+	if !twilio.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	rec := server.AddRecording("CA123", 0)
+	client := server.Client()
+	if err := client.Recordings.Delete(context.Background(), rec.Sid); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Recordings.Get(context.Background(), rec.Sid); !twilio.IsNotFound(err) {
+		t.Errorf("expected deleted recording to be gone, got %v", err)
+	}
+}
+
+func TestMedia(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+	msg, err := client.Messages.SendMessage(NumberValid, "+14105551234", "hello", []*url.URL{
+		{Scheme: "https", Host: "example.com", Path: "/cat.jpg"},
+		{Scheme: "https", Host: "example.com", Path: "/dog.png"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.NumMedia != 2 {
+		t.Errorf("expected 2 media, got %d", msg.NumMedia)
+	}
+	page, err := client.Media.GetPage(ctx, msg.Sid, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.MediaList) != 2 {
+		t.Fatalf("expected 2 media, got %d", len(page.MediaList))
+	}
+	media := page.MediaList[0]
+	if media.ParentSid != msg.Sid || media.ContentType != "image/png" {
+		t.Errorf("bad media: %#v", media)
+	}
+	if err := client.Media.Delete(ctx, msg.Sid, media.Sid); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Media.Get(ctx, msg.Sid, media.Sid); !twilio.IsNotFound(err) {
+		t.Errorf("expected deleted media to be gone, got %v", err)
+	}
+	page, err = client.Media.GetPage(ctx, msg.Sid, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.MediaList) != 1 {
+		t.Errorf("expected 1 media after delete, got %d", len(page.MediaList))
+	}
+	if _, err := client.Media.GetPage(ctx, "MM123", nil); !twilio.IsNotFound(err) {
+		t.Errorf("expected not found for media of a missing message, got %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package twiml_test
+
+import (
+	"fmt"
+
+	"github.com/kevinburke/twilio-go/twiml"
+)
+
+func Example() {
+	resp := &twiml.Response{Verbs: []twiml.Verb{
+		&twiml.Say{Text: "Connecting you to sales."},
+		&twiml.Dial{Nouns: []twiml.Noun{&twiml.Number{Number: "+14105551234"}}},
+	}}
+	b, _ := resp.Marshal()
+	fmt.Println(string(b))
+	// Output:
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <Response><Say>Connecting you to sales.</Say><Dial><Number>+14105551234</Number></Dial></Response>
+}
@@ -0,0 +1,60 @@
+package twiml
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// Message replies to the sender, or sends a message to another number.
+// https://www.twilio.com/docs/messaging/twiml/message
+type Message struct {
+	XMLName        xml.Name `xml:"Message"`
+	To             string   `xml:"to,attr,omitempty"`
+	From           string   `xml:"from,attr,omitempty"`
+	Action         string   `xml:"action,attr,omitempty"`
+	Method         string   `xml:"method,attr,omitempty"`
+	StatusCallback string   `xml:"statusCallback,attr,omitempty"`
+	Body           string   `xml:"Body,omitempty"`
+	Media          []Media
+}
+
+// Media is the URL of an image or other file to attach to a Message.
+// https://www.twilio.com/docs/messaging/twiml/message#nouns
+type Media struct {
+	XMLName xml.Name `xml:"Media"`
+	URL     string   `xml:",chardata"`
+}
+
+func (*Message) isMessagingVerb()  {}
+func (*Redirect) isMessagingVerb() {}
+
+// UnmarshalXML accepts the body either as a <Body> element or as text
+// directly inside the <Message>.
+func (m *Message) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type message Message
+	if err := decodeAttrs((*message)(m), start); err != nil {
+		return err
+	}
+	text, err := decodeMixed(d, func(se xml.StartElement) error {
+		switch se.Name.Local {
+		case "Body":
+			return d.DecodeElement(&m.Body, &se)
+		case "Media":
+			var media Media
+			if err := d.DecodeElement(&media, &se); err != nil {
+				return err
+			}
+			m.Media = append(m.Media, media)
+			return nil
+		default:
+			return fmt.Errorf("twiml: unknown noun <%s> in <Message>", se.Name.Local)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if m.Body == "" {
+		m.Body = text
+	}
+	return nil
+}
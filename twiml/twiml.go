@@ -0,0 +1,169 @@
+// Package twiml builds TwiML documents, the XML instructions Twilio follows
+// when it answers a call or receives a message.
+//
+// Build a Response (for voice) or a MessagingResponse (for messaging) out of
+// verbs, and write it to your webhook's http.ResponseWriter:
+//
+//	resp := &twiml.Response{Verbs: []twiml.Verb{
+//		&twiml.Say{Text: "Connecting you now."},
+//		&twiml.Dial{Nouns: []twiml.Noun{&twiml.Number{Number: "+14105551234"}}},
+//	}}
+//	resp.ServeHTTP(w, r)
+//
+// Parse and ParseMessaging read TwiML back into the same types, which is
+// useful for testing webhook handlers.
+//
+// For more information, see https://www.twilio.com/docs/voice/twiml and
+// https://www.twilio.com/docs/messaging/twiml.
+package twiml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+)
+
+const contentType = "text/xml; charset=utf-8"
+
+// A Response is a TwiML document for a voice call.
+type Response struct {
+	XMLName xml.Name `xml:"Response"`
+	Verbs   []Verb
+}
+
+// A Verb is an instruction that can appear in a voice Response: Say, Play,
+// Dial, Gather, Record, Enqueue, Redirect, Pause, Hangup or Reject.
+type Verb interface {
+	isVerb()
+}
+
+// A MessagingResponse is a TwiML document for an incoming message.
+type MessagingResponse struct {
+	XMLName xml.Name `xml:"Response"`
+	Verbs   []MessagingVerb
+}
+
+// A MessagingVerb is an instruction that can appear in a MessagingResponse:
+// Message or Redirect.
+type MessagingVerb interface {
+	isMessagingVerb()
+}
+
+// Marshal returns the XML encoding of r, including the XML header.
+func (r *Response) Marshal() ([]byte, error) {
+	return marshal(r)
+}
+
+// ServeHTTP writes r to w as a TwiML document.
+func (r *Response) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	serve(w, r)
+}
+
+// Marshal returns the XML encoding of r, including the XML header.
+func (r *MessagingResponse) Marshal() ([]byte, error) {
+	return marshal(r)
+}
+
+// ServeHTTP writes r to w as a TwiML document.
+func (r *MessagingResponse) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	serve(w, r)
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	b, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
+
+func serve(w http.ResponseWriter, v interface{}) {
+	b, err := marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write(b)
+}
+
+// Parse parses a voice TwiML document.
+func Parse(b []byte) (*Response, error) {
+	r := new(Response)
+	if err := xml.NewDecoder(bytes.NewReader(b)).Decode(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ParseMessaging parses a messaging TwiML document.
+func ParseMessaging(b []byte) (*MessagingResponse, error) {
+	r := new(MessagingResponse)
+	if err := xml.NewDecoder(bytes.NewReader(b)).Decode(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Response) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	r.XMLName = start.Name
+	return decodeChildren(d, func(se xml.StartElement) error {
+		v, ok := newVerb(se.Name.Local)
+		if !ok {
+			return fmt.Errorf("twiml: unknown voice verb <%s>", se.Name.Local)
+		}
+		if err := d.DecodeElement(v, &se); err != nil {
+			return err
+		}
+		r.Verbs = append(r.Verbs, v)
+		return nil
+	})
+}
+
+func (r *MessagingResponse) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	r.XMLName = start.Name
+	return decodeChildren(d, func(se xml.StartElement) error {
+		var v MessagingVerb
+		switch se.Name.Local {
+		case "Message":
+			v = new(Message)
+		case "Redirect":
+			v = new(Redirect)
+		default:
+			return fmt.Errorf("twiml: unknown messaging verb <%s>", se.Name.Local)
+		}
+		if err := d.DecodeElement(v, &se); err != nil {
+			return err
+		}
+		r.Verbs = append(r.Verbs, v)
+		return nil
+	})
+}
+
+// decodeChildren calls fn for each child element of the element that was just
+// started, and returns the character data between them.
+func decodeChildren(d *xml.Decoder, fn func(xml.StartElement) error) error {
+	_, err := decodeMixed(d, fn)
+	return err
+}
+
+func decodeMixed(d *xml.Decoder, fn func(xml.StartElement) error) (string, error) {
+	var text bytes.Buffer
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if err := fn(t); err != nil {
+				return "", err
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			return string(bytes.TrimSpace(text.Bytes())), nil
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package twiml
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarshalVoice(t *testing.T) {
+	t.Parallel()
+	r := &Response{Verbs: []Verb{
+		&Say{Text: "Hello & welcome", Voice: "alice"},
+		&Gather{Action: "/menu", NumDigits: 1, Verbs: []Verb{
+			&Play{URL: "https://example.com/menu.mp3"},
+		}},
+		&Dial{CallerID: "+14105551234", Nouns: []Noun{
+			&Number{Number: "+14105556789", SendDigits: "wwww1928"},
+			&Client{Identity: "alice"},
+		}},
+		&Hangup{},
+	}}
+	b, err := r.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<Response><Say voice="alice">Hello &amp; welcome</Say><Gather action="/menu" numDigits="1"><Play>https://example.com/menu.mp3</Play></Gather><Dial callerId="+14105551234"><Number sendDigits="wwww1928">+14105556789</Number><Client>alice</Client></Dial><Hangup></Hangup></Response>`
+	if string(b) != want {
+		t.Errorf("bad TwiML:\ngot  %s\nwant %s", b, want)
+	}
+}
+
+func TestRoundTripVoice(t *testing.T) {
+	t.Parallel()
+	r := &Response{Verbs: []Verb{
+		&Say{Text: "Please hold", Loop: 2},
+		&Enqueue{Name: "support", WaitURL: "/wait"},
+		&Dial{Number: "+14105551234", Timeout: 10},
+		&Dial{Nouns: []Noun{&Conference{Name: "room", Beep: "false"}}},
+		&Dial{Nouns: []Noun{&Queue{Name: "support"}, &Sip{URI: "sip:alice@example.com"}}},
+		&Record{MaxLength: 30, PlayBeep: "false"},
+		&Pause{Length: 3},
+		&Redirect{URL: "/next", Method: "POST"},
+		&Reject{Reason: "busy"},
+	}}
+	b, err := r.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := Parse(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(normalize(r), normalize(parsed)) {
+		t.Errorf("round trip mismatch:\ngot  %#v\nwant %#v", parsed.Verbs, r.Verbs)
+	}
+}
+
+// normalize re-marshals r so that XMLName fields are filled in.
+func normalize(r *Response) string {
+	b, err := r.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+func TestParseHandwritten(t *testing.T) {
+	t.Parallel()
+	r, err := Parse([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<Response>
+    <Gather input="speech dtmf" timeout="5">
+        <Say>Press 1 for sales.</Say>
+    </Gather>
+    <Dial>415-123-4567</Dial>
+</Response>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Verbs) != 2 {
+		t.Fatalf("expected 2 verbs, got %d", len(r.Verbs))
+	}
+	g, ok := r.Verbs[0].(*Gather)
+	if !ok {
+		t.Fatalf("expected *Gather, got %T", r.Verbs[0])
+	}
+	if g.Input != "speech dtmf" || g.Timeout != 5 || len(g.Verbs) != 1 {
+		t.Errorf("bad Gather: %#v", g)
+	}
+	if say := g.Verbs[0].(*Say); say.Text != "Press 1 for sales." {
+		t.Errorf("bad Say: %#v", say)
+	}
+	if d := r.Verbs[1].(*Dial); d.Number != "415-123-4567" || len(d.Nouns) != 0 {
+		t.Errorf("bad Dial: %#v", d)
+	}
+}
+
+func TestParseUnknownVerb(t *testing.T) {
+	t.Parallel()
+	_, err := Parse([]byte(`<Response><Shout>hi</Shout></Response>`))
+	if err == nil || !strings.Contains(err.Error(), "<Shout>") {
+		t.Errorf("expected unknown verb error, got %v", err)
+	}
+	_, err = Parse([]byte(`<Response><Gather><Dial>+14105551234</Dial></Gather></Response>`))
+	if err == nil || !strings.Contains(err.Error(), "not allowed in <Gather>") {
+		t.Errorf("expected nested verb error, got %v", err)
+	}
+}
+
+func TestMessaging(t *testing.T) {
+	t.Parallel()
+	r := &MessagingResponse{Verbs: []MessagingVerb{
+		&Message{To: "+14105551234", Body: "Your order shipped", Media: []Media{
+			{URL: "https://example.com/label.png"},
+		}},
+		&Redirect{URL: "/fallback"},
+	}}
+	b, err := r.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<Response><Message to="+14105551234"><Body>Your order shipped</Body><Media>https://example.com/label.png</Media></Message><Redirect>/fallback</Redirect></Response>`
+	if string(b) != want {
+		t.Errorf("bad TwiML:\ngot  %s\nwant %s", b, want)
+	}
+	parsed, err := ParseMessaging(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := parsed.Verbs[0].(*Message)
+	if msg.Body != "Your order shipped" || len(msg.Media) != 1 || msg.To != "+14105551234" {
+		t.Errorf("bad Message: %#v", msg)
+	}
+
+	parsed, err = ParseMessaging([]byte(`<Response><Message>Thanks!</Message></Response>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg := parsed.Verbs[0].(*Message); msg.Body != "Thanks!" {
+		t.Errorf("expected inline body to be parsed, got %q", msg.Body)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	t.Parallel()
+	w := httptest.NewRecorder()
+	r := &MessagingResponse{Verbs: []MessagingVerb{&Message{Body: "hi"}}}
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/sms", nil))
+	if ctype := w.Header().Get("Content-Type"); ctype != "text/xml; charset=utf-8" {
+		t.Errorf("bad Content-Type: %s", ctype)
+	}
+	if !strings.HasSuffix(w.Body.String(), "<Response><Message><Body>hi</Body></Message></Response>") {
+		t.Errorf("bad body: %s", w.Body.String())
+	}
+}
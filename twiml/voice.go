@@ -0,0 +1,326 @@
+package twiml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// Say reads text to the caller.
+// https://www.twilio.com/docs/voice/twiml/say
+type Say struct {
+	XMLName  xml.Name `xml:"Say"`
+	Text     string   `xml:",chardata"`
+	Voice    string   `xml:"voice,attr,omitempty"`
+	Language string   `xml:"language,attr,omitempty"`
+	// Loop is the number of times to repeat the text. Zero means the Twilio
+	// default, which is to say it once.
+	Loop int `xml:"loop,attr,omitempty"`
+}
+
+// Play plays an audio file to the caller, or sends DTMF digits.
+// https://www.twilio.com/docs/voice/twiml/play
+type Play struct {
+	XMLName xml.Name `xml:"Play"`
+	URL     string   `xml:",chardata"`
+	Loop    int      `xml:"loop,attr,omitempty"`
+	Digits  string   `xml:"digits,attr,omitempty"`
+}
+
+// Dial connects the caller to another party. Set either Number, to dial a
+// single phone number, or Nouns, to dial one or more Numbers, Clients, Sip
+// endpoints, or a single Conference or Queue.
+// https://www.twilio.com/docs/voice/twiml/dial
+type Dial struct {
+	XMLName                       xml.Name `xml:"Dial"`
+	Number                        string   `xml:",chardata"`
+	Action                        string   `xml:"action,attr,omitempty"`
+	Method                        string   `xml:"method,attr,omitempty"`
+	Timeout                       int      `xml:"timeout,attr,omitempty"`
+	HangupOnStar                  bool     `xml:"hangupOnStar,attr,omitempty"`
+	TimeLimit                     int      `xml:"timeLimit,attr,omitempty"`
+	CallerID                      string   `xml:"callerId,attr,omitempty"`
+	Record                        string   `xml:"record,attr,omitempty"`
+	RecordingStatusCallback       string   `xml:"recordingStatusCallback,attr,omitempty"`
+	RecordingStatusCallbackMethod string   `xml:"recordingStatusCallbackMethod,attr,omitempty"`
+	Trim                          string   `xml:"trim,attr,omitempty"`
+	AnswerOnBridge                bool     `xml:"answerOnBridge,attr,omitempty"`
+	RingTone                      string   `xml:"ringTone,attr,omitempty"`
+	Nouns                         []Noun
+}
+
+// A Noun is something a Dial verb can connect to: a Number, Client,
+// Conference, Queue or Sip endpoint.
+type Noun interface {
+	isNoun()
+}
+
+// Number is a phone number to Dial.
+// https://www.twilio.com/docs/voice/twiml/number
+type Number struct {
+	XMLName              xml.Name `xml:"Number"`
+	Number               string   `xml:",chardata"`
+	SendDigits           string   `xml:"sendDigits,attr,omitempty"`
+	URL                  string   `xml:"url,attr,omitempty"`
+	Method               string   `xml:"method,attr,omitempty"`
+	StatusCallback       string   `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackMethod string   `xml:"statusCallbackMethod,attr,omitempty"`
+	StatusCallbackEvent  string   `xml:"statusCallbackEvent,attr,omitempty"`
+}
+
+// Client is a Twilio Client (Voice SDK) identity to Dial.
+// https://www.twilio.com/docs/voice/twiml/client
+type Client struct {
+	XMLName              xml.Name `xml:"Client"`
+	Identity             string   `xml:",chardata"`
+	URL                  string   `xml:"url,attr,omitempty"`
+	Method               string   `xml:"method,attr,omitempty"`
+	StatusCallback       string   `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackMethod string   `xml:"statusCallbackMethod,attr,omitempty"`
+	StatusCallbackEvent  string   `xml:"statusCallbackEvent,attr,omitempty"`
+}
+
+// Conference connects the caller to the named conference room.
+// https://www.twilio.com/docs/voice/twiml/conference
+type Conference struct {
+	XMLName xml.Name `xml:"Conference"`
+	Name    string   `xml:",chardata"`
+	Muted   bool     `xml:"muted,attr,omitempty"`
+	// Beep is one of "true", "false", "onEnter" or "onExit". Empty means the
+	// Twilio default, "true".
+	Beep                   string `xml:"beep,attr,omitempty"`
+	StartConferenceOnEnter string `xml:"startConferenceOnEnter,attr,omitempty"`
+	EndConferenceOnExit    bool   `xml:"endConferenceOnExit,attr,omitempty"`
+	WaitURL                string `xml:"waitUrl,attr,omitempty"`
+	WaitMethod             string `xml:"waitMethod,attr,omitempty"`
+	MaxParticipants        int    `xml:"maxParticipants,attr,omitempty"`
+	Record                 string `xml:"record,attr,omitempty"`
+	Coach                  string `xml:"coach,attr,omitempty"`
+	StatusCallback         string `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackMethod   string `xml:"statusCallbackMethod,attr,omitempty"`
+	StatusCallbackEvent    string `xml:"statusCallbackEvent,attr,omitempty"`
+}
+
+// Queue connects the caller to the first caller waiting in the named queue.
+// https://www.twilio.com/docs/voice/twiml/queue
+type Queue struct {
+	XMLName xml.Name `xml:"Queue"`
+	Name    string   `xml:",chardata"`
+	URL     string   `xml:"url,attr,omitempty"`
+	Method  string   `xml:"method,attr,omitempty"`
+}
+
+// Sip is a SIP URI to Dial.
+// https://www.twilio.com/docs/voice/twiml/sip
+type Sip struct {
+	XMLName              xml.Name `xml:"Sip"`
+	URI                  string   `xml:",chardata"`
+	Username             string   `xml:"username,attr,omitempty"`
+	Password             string   `xml:"password,attr,omitempty"`
+	URL                  string   `xml:"url,attr,omitempty"`
+	Method               string   `xml:"method,attr,omitempty"`
+	StatusCallback       string   `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackMethod string   `xml:"statusCallbackMethod,attr,omitempty"`
+	StatusCallbackEvent  string   `xml:"statusCallbackEvent,attr,omitempty"`
+}
+
+// Gather collects digits or speech from the caller. Verbs may contain Say,
+// Play and Pause verbs to prompt the caller.
+// https://www.twilio.com/docs/voice/twiml/gather
+type Gather struct {
+	XMLName             xml.Name `xml:"Gather"`
+	Action              string   `xml:"action,attr,omitempty"`
+	Method              string   `xml:"method,attr,omitempty"`
+	Timeout             int      `xml:"timeout,attr,omitempty"`
+	FinishOnKey         string   `xml:"finishOnKey,attr,omitempty"`
+	NumDigits           int      `xml:"numDigits,attr,omitempty"`
+	Input               string   `xml:"input,attr,omitempty"`
+	Language            string   `xml:"language,attr,omitempty"`
+	Hints               string   `xml:"hints,attr,omitempty"`
+	SpeechTimeout       string   `xml:"speechTimeout,attr,omitempty"`
+	ActionOnEmptyResult bool     `xml:"actionOnEmptyResult,attr,omitempty"`
+	Verbs               []Verb
+}
+
+// Record records the caller's voice.
+// https://www.twilio.com/docs/voice/twiml/record
+type Record struct {
+	XMLName     xml.Name `xml:"Record"`
+	Action      string   `xml:"action,attr,omitempty"`
+	Method      string   `xml:"method,attr,omitempty"`
+	Timeout     int      `xml:"timeout,attr,omitempty"`
+	FinishOnKey string   `xml:"finishOnKey,attr,omitempty"`
+	MaxLength   int      `xml:"maxLength,attr,omitempty"`
+	// PlayBeep is "true" or "false". Empty means the Twilio default, "true".
+	PlayBeep                      string `xml:"playBeep,attr,omitempty"`
+	Trim                          string `xml:"trim,attr,omitempty"`
+	RecordingStatusCallback       string `xml:"recordingStatusCallback,attr,omitempty"`
+	RecordingStatusCallbackMethod string `xml:"recordingStatusCallbackMethod,attr,omitempty"`
+	Transcribe                    bool   `xml:"transcribe,attr,omitempty"`
+	TranscribeCallback            string `xml:"transcribeCallback,attr,omitempty"`
+}
+
+// Enqueue places the caller in the named queue.
+// https://www.twilio.com/docs/voice/twiml/enqueue
+type Enqueue struct {
+	XMLName       xml.Name `xml:"Enqueue"`
+	Name          string   `xml:",chardata"`
+	Action        string   `xml:"action,attr,omitempty"`
+	Method        string   `xml:"method,attr,omitempty"`
+	WaitURL       string   `xml:"waitUrl,attr,omitempty"`
+	WaitURLMethod string   `xml:"waitUrlMethod,attr,omitempty"`
+	WorkflowSid   string   `xml:"workflowSid,attr,omitempty"`
+}
+
+// Redirect transfers control to the TwiML at URL. It can be used in both
+// voice and messaging responses.
+// https://www.twilio.com/docs/voice/twiml/redirect
+type Redirect struct {
+	XMLName xml.Name `xml:"Redirect"`
+	URL     string   `xml:",chardata"`
+	Method  string   `xml:"method,attr,omitempty"`
+}
+
+// Pause waits silently for Length seconds.
+// https://www.twilio.com/docs/voice/twiml/pause
+type Pause struct {
+	XMLName xml.Name `xml:"Pause"`
+	Length  int      `xml:"length,attr,omitempty"`
+}
+
+// Hangup ends the call.
+// https://www.twilio.com/docs/voice/twiml/hangup
+type Hangup struct {
+	XMLName xml.Name `xml:"Hangup"`
+}
+
+// Reject rejects an incoming call without answering it, so the caller is not
+// billed.
+// https://www.twilio.com/docs/voice/twiml/reject
+type Reject struct {
+	XMLName xml.Name `xml:"Reject"`
+	// Reason is "rejected" (the default) or "busy".
+	Reason string `xml:"reason,attr,omitempty"`
+}
+
+func (*Say) isVerb()      {}
+func (*Play) isVerb()     {}
+func (*Dial) isVerb()     {}
+func (*Gather) isVerb()   {}
+func (*Record) isVerb()   {}
+func (*Enqueue) isVerb()  {}
+func (*Redirect) isVerb() {}
+func (*Pause) isVerb()    {}
+func (*Hangup) isVerb()   {}
+func (*Reject) isVerb()   {}
+
+func (*Number) isNoun()     {}
+func (*Client) isNoun()     {}
+func (*Conference) isNoun() {}
+func (*Queue) isNoun()      {}
+func (*Sip) isNoun()        {}
+
+func newVerb(name string) (Verb, bool) {
+	switch name {
+	case "Say":
+		return new(Say), true
+	case "Play":
+		return new(Play), true
+	case "Dial":
+		return new(Dial), true
+	case "Gather":
+		return new(Gather), true
+	case "Record":
+		return new(Record), true
+	case "Enqueue":
+		return new(Enqueue), true
+	case "Redirect":
+		return new(Redirect), true
+	case "Pause":
+		return new(Pause), true
+	case "Hangup":
+		return new(Hangup), true
+	case "Reject":
+		return new(Reject), true
+	default:
+		return nil, false
+	}
+}
+
+func newNoun(name string) (Noun, bool) {
+	switch name {
+	case "Number":
+		return new(Number), true
+	case "Client":
+		return new(Client), true
+	case "Conference":
+		return new(Conference), true
+	case "Queue":
+		return new(Queue), true
+	case "Sip":
+		return new(Sip), true
+	default:
+		return nil, false
+	}
+}
+
+func (dl *Dial) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type dial Dial
+	if err := decodeAttrs((*dial)(dl), start); err != nil {
+		return err
+	}
+	text, err := decodeMixed(d, func(se xml.StartElement) error {
+		n, ok := newNoun(se.Name.Local)
+		if !ok {
+			return fmt.Errorf("twiml: unknown noun <%s> in <Dial>", se.Name.Local)
+		}
+		if err := d.DecodeElement(n, &se); err != nil {
+			return err
+		}
+		dl.Nouns = append(dl.Nouns, n)
+		return nil
+	})
+	dl.Number = text
+	return err
+}
+
+func (g *Gather) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type gather Gather
+	if err := decodeAttrs((*gather)(g), start); err != nil {
+		return err
+	}
+	return decodeChildren(d, func(se xml.StartElement) error {
+		var v Verb
+		switch se.Name.Local {
+		case "Say", "Play", "Pause":
+			v, _ = newVerb(se.Name.Local)
+		default:
+			return fmt.Errorf("twiml: <%s> is not allowed in <Gather>", se.Name.Local)
+		}
+		if err := d.DecodeElement(v, &se); err != nil {
+			return err
+		}
+		g.Verbs = append(g.Verbs, v)
+		return nil
+	})
+}
+
+// decodeAttrs decodes the attributes of start into v, which must not have its
+// own UnmarshalXML method.
+func decodeAttrs(v interface{}, start xml.StartElement) error {
+	toks := &tokenList{toks: []xml.Token{start, start.End()}}
+	return xml.NewTokenDecoder(toks).Decode(v)
+}
+
+type tokenList struct {
+	toks []xml.Token
+}
+
+func (t *tokenList) Token() (xml.Token, error) {
+	if len(t.toks) == 0 {
+		return nil, io.EOF
+	}
+	tok := t.toks[0]
+	t.toks = t.toks[1:]
+	return tok, nil
+}
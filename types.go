@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/url"
 	"strconv"
 	"strings"
@@ -67,6 +68,47 @@ func (pn PhoneNumber) Local() string {
 	return libphonenumber.Format(num, libphonenumber.NATIONAL)
 }
 
+// Masked returns the phone number with all but the country code and the last
+// two digits replaced with asterisks, for example, "+14105554092" is returned
+// as "+1********92". If the phone number cannot be parsed, every digit but
+// the last two is masked.
+func (pn PhoneNumber) Masked() string {
+	num, err := libphonenumber.Parse(string(pn), "US")
+	if err != nil {
+		return maskDigits(string(pn), 2)
+	}
+	cc := "+" + strconv.Itoa(int(num.GetCountryCode()))
+	return cc + maskDigits(libphonenumber.GetNationalSignificantNumber(num), 2)
+}
+
+// LogValue implements slog.LogValuer, so phone numbers are masked when they
+// are logged.
+func (pn PhoneNumber) LogValue() slog.Value {
+	return slog.StringValue(pn.Masked())
+}
+
+// maskDigits replaces every digit in s but the last keep digits with an
+// asterisk.
+func maskDigits(s string, keep int) string {
+	digits := 0
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	b := []byte(s)
+	for i := range b {
+		if b[i] < '0' || b[i] > '9' {
+			continue
+		}
+		if digits > keep {
+			b[i] = '*'
+		}
+		digits--
+	}
+	return string(b)
+}
+
 // A uintStr is sent back from Twilio as a str, but should be parsed as a uint.
 type uintStr uint
 
@@ -151,7 +193,11 @@ func (t *TwilioTime) UnmarshalJSON(b []byte) error {
 	if err != nil {
 		tim, err = time.Parse(TimeLayout, *s)
 		if err != nil {
-			return err
+			// Usage Records report start and end dates without a time.
+			tim, err = time.Parse(APISearchLayout, *s)
+			if err != nil {
+				return err
+			}
 		}
 	}
 	*t = TwilioTime{Time: tim, Valid: true}
@@ -192,6 +238,15 @@ func price(unit string, amount string) string {
 	} else {
 		amount = "-" + amount
 	}
+	return formatPrice(unit, amount)
+}
+
+// formatPrice trims trailing zeros from amount and adds the currency symbol
+// for unit in front of it, without changing its sign.
+func formatPrice(unit string, amount string) string {
+	if len(amount) == 0 {
+		return amount
+	}
 	for strings.Contains(amount, ".") && strings.HasSuffix(amount, "0") {
 		amount = amount[:len(amount)-1]
 	}
@@ -287,6 +342,7 @@ func (h *Values) UnmarshalJSON(b []byte) error {
 const StatusAccepted = Status("accepted")
 const StatusSent = Status("sent")
 const StatusUndelivered = Status("undelivered")
+const StatusScheduled = Status("scheduled")
 
 // Call statuses
 
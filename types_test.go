@@ -26,6 +26,25 @@ func TestPhoneNumberFriendly(t *testing.T) {
 	}
 }
 
+var pnMaskTestCases = []struct {
+	in       PhoneNumber
+	expected string
+}{
+	{PhoneNumber("+14105554092"), "+1********92"},
+	{PhoneNumber("+41446681800"), "+41*******00"},
+	{PhoneNumber("+447700900123"), "+44********23"},
+	{PhoneNumber("blah"), "blah"},
+}
+
+func TestPhoneNumberMasked(t *testing.T) {
+	t.Parallel()
+	for _, tt := range pnMaskTestCases {
+		if m := tt.in.Masked(); m != tt.expected {
+			t.Errorf("Masked(%v): got %s, want %s", tt.in, m, tt.expected)
+		}
+	}
+}
+
 var pnParseTestCases = []struct {
 	in  string
 	out PhoneNumber
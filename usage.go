@@ -0,0 +1,228 @@
+package twilio
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+const accountUsageRecordsPathPart = "Usage/Records"
+const usageTriggersPathPart = "Usage/Triggers"
+
+// A UsageCategory is a type of usage that Twilio bills for, like "calls" or
+// "sms". For the full list, see
+// https://www.twilio.com/docs/usage/api/usage-record#usage-categories.
+type UsageCategory string
+
+const (
+	UsageCategoryCalls          UsageCategory = "calls"
+	UsageCategoryCallsInbound   UsageCategory = "calls-inbound"
+	UsageCategoryCallsOutbound  UsageCategory = "calls-outbound"
+	UsageCategoryMMS            UsageCategory = "mms"
+	UsageCategoryPhoneNumbers   UsageCategory = "phonenumbers"
+	UsageCategoryRecordings     UsageCategory = "recordings"
+	UsageCategorySMS            UsageCategory = "sms"
+	UsageCategorySMSInbound     UsageCategory = "sms-inbound"
+	UsageCategorySMSOutbound    UsageCategory = "sms-outbound"
+	UsageCategoryTotalPrice     UsageCategory = "totalprice"
+	UsageCategoryTranscriptions UsageCategory = "transcriptions"
+)
+
+// A UsageInterval groups Usage Records by period.
+type UsageInterval string
+
+const (
+	UsageIntervalDaily     UsageInterval = "Daily"
+	UsageIntervalMonthly   UsageInterval = "Monthly"
+	UsageIntervalYearly    UsageInterval = "Yearly"
+	UsageIntervalToday     UsageInterval = "Today"
+	UsageIntervalYesterday UsageInterval = "Yesterday"
+	UsageIntervalThisMonth UsageInterval = "ThisMonth"
+	UsageIntervalLastMonth UsageInterval = "LastMonth"
+	UsageIntervalAllTime   UsageInterval = "AllTime"
+)
+
+type UsageRecordService struct {
+	client *Client
+}
+
+// An AccountUsageRecord is the usage in a single UsageCategory over a period
+// of time. (UsageRecord is the Super SIM usage record type.)
+//
+// Count, Usage and Price may be reported by the API as a string or a number,
+// and are decoded as a json.Number.
+type AccountUsageRecord struct {
+	AccountSid  string        `json:"account_sid"`
+	APIVersion  string        `json:"api_version"`
+	AsOf        TwilioTime    `json:"as_of"`
+	Category    UsageCategory `json:"category"`
+	Count       json.Number   `json:"count"`
+	CountUnit   string        `json:"count_unit"`
+	Description string        `json:"description"`
+	StartDate   TwilioTime    `json:"start_date"`
+	EndDate     TwilioTime    `json:"end_date"`
+	Price       json.Number   `json:"price"`
+	PriceUnit   string        `json:"price_unit"`
+	Usage       json.Number   `json:"usage"`
+	UsageUnit   string        `json:"usage_unit"`
+	URI         string        `json:"uri"`
+}
+
+// FriendlyPrice adds an appropriate currency symbol in front of the Price. For
+// example, a PriceUnit of "USD" and a Price of "1.25" is reported as "$1.25".
+//
+// Unlike the Price on a Call or Message, usage prices are reported by the API
+// as positive numbers.
+func (r *AccountUsageRecord) FriendlyPrice() string {
+	if r == nil {
+		return ""
+	}
+	return formatPrice(r.PriceUnit, string(r.Price))
+}
+
+type AccountUsageRecordPage struct {
+	Page
+	UsageRecords []*AccountUsageRecord `json:"usage_records"`
+}
+
+// GetPage returns a single Page of Usage Records for the whole lifetime of the
+// account. Filter by "Category", "StartDate" and "EndDate" (formatted with
+// APISearchLayout), or set "IncludeSubaccounts" to "false" to exclude usage
+// by subaccounts.
+func (u *UsageRecordService) GetPage(ctx context.Context, data url.Values) (*AccountUsageRecordPage, error) {
+	return u.GetPageIterator(data).Next(ctx)
+}
+
+// GetIntervalPage returns a single Page of Usage Records grouped by the given
+// interval, for example one record per category per day with
+// UsageIntervalDaily. data accepts the same filters as GetPage.
+func (u *UsageRecordService) GetIntervalPage(ctx context.Context, interval UsageInterval, data url.Values) (*AccountUsageRecordPage, error) {
+	return u.GetIntervalPageIterator(interval, data).Next(ctx)
+}
+
+type AccountUsageRecordPageIterator struct {
+	p *PageIterator
+}
+
+// GetPageIterator returns an AccountUsageRecordPageIterator with the given
+// page filters. Call iterator.Next() to get the first page of resources (and
+// again to retrieve subsequent pages).
+func (u *UsageRecordService) GetPageIterator(data url.Values) *AccountUsageRecordPageIterator {
+	iter := NewPageIterator(u.client, data, accountUsageRecordsPathPart)
+	return &AccountUsageRecordPageIterator{
+		p: iter,
+	}
+}
+
+// GetIntervalPageIterator returns an AccountUsageRecordPageIterator over Usage
+// Records grouped by the given interval.
+func (u *UsageRecordService) GetIntervalPageIterator(interval UsageInterval, data url.Values) *AccountUsageRecordPageIterator {
+	iter := NewPageIterator(u.client, data, accountUsageRecordsPathPart+"/"+string(interval))
+	return &AccountUsageRecordPageIterator{
+		p: iter,
+	}
+}
+
+// Next returns the next page of resources. If there are no more resources,
+// NoMoreResults is returned.
+func (u *AccountUsageRecordPageIterator) Next(ctx context.Context) (*AccountUsageRecordPage, error) {
+	up := new(AccountUsageRecordPage)
+	err := u.p.Next(ctx, up)
+	if err != nil {
+		return nil, err
+	}
+	u.p.SetNextPageURI(up.NextPageURI)
+	return up, nil
+}
+
+type UsageTriggerService struct {
+	client *Client
+}
+
+// A UsageTrigger makes a request to CallbackURL when usage in a
+// UsageCategory crosses TriggerValue.
+type UsageTrigger struct {
+	Sid            string     `json:"sid"`
+	AccountSid     string     `json:"account_sid"`
+	APIVersion     string     `json:"api_version"`
+	CallbackMethod string     `json:"callback_method"`
+	CallbackURL    string     `json:"callback_url"`
+	CurrentValue   string     `json:"current_value"`
+	DateCreated    TwilioTime `json:"date_created"`
+	DateFired      TwilioTime `json:"date_fired"`
+	DateUpdated    TwilioTime `json:"date_updated"`
+	FriendlyName   string     `json:"friendly_name"`
+	// One of "", "daily", "monthly" or "yearly".
+	Recurring string `json:"recurring"`
+	// One of "count", "usage" or "price".
+	TriggerBy      string        `json:"trigger_by"`
+	TriggerValue   string        `json:"trigger_value"`
+	UsageCategory  UsageCategory `json:"usage_category"`
+	UsageRecordURI string        `json:"usage_record_uri"`
+	URI            string        `json:"uri"`
+}
+
+type UsageTriggerPage struct {
+	Page
+	UsageTriggers []*UsageTrigger `json:"usage_triggers"`
+}
+
+func (u *UsageTriggerService) Get(ctx context.Context, sid string) (*UsageTrigger, error) {
+	trigger := new(UsageTrigger)
+	err := u.client.GetResource(ctx, usageTriggersPathPart, sid, trigger)
+	return trigger, err
+}
+
+// Create a UsageTrigger. data must include "CallbackUrl", "TriggerValue" and
+// "UsageCategory"; for the full list of parameters, see
+// https://www.twilio.com/docs/usage/api/usage-trigger#create-a-usagetrigger-resource.
+func (u *UsageTriggerService) Create(ctx context.Context, data url.Values) (*UsageTrigger, error) {
+	trigger := new(UsageTrigger)
+	err := u.client.CreateResource(ctx, usageTriggersPathPart, data, trigger)
+	return trigger, err
+}
+
+// Update the UsageTrigger with the given data. Valid parameters are
+// "CallbackMethod", "CallbackUrl" and "FriendlyName".
+func (u *UsageTriggerService) Update(ctx context.Context, sid string, data url.Values) (*UsageTrigger, error) {
+	trigger := new(UsageTrigger)
+	err := u.client.UpdateResource(ctx, usageTriggersPathPart, sid, data, trigger)
+	return trigger, err
+}
+
+// Delete the UsageTrigger with the given sid. If the UsageTrigger has already
+// been deleted, or does not exist, Delete returns nil. If another error or a
+// timeout occurs, the error is returned.
+func (u *UsageTriggerService) Delete(ctx context.Context, sid string) error {
+	return u.client.DeleteResource(ctx, usageTriggersPathPart, sid)
+}
+
+func (u *UsageTriggerService) GetPage(ctx context.Context, data url.Values) (*UsageTriggerPage, error) {
+	return u.GetPageIterator(data).Next(ctx)
+}
+
+type UsageTriggerPageIterator struct {
+	p *PageIterator
+}
+
+// GetPageIterator returns a UsageTriggerPageIterator with the given page
+// filters. Call iterator.Next() to get the first page of resources (and again
+// to retrieve subsequent pages).
+func (u *UsageTriggerService) GetPageIterator(data url.Values) *UsageTriggerPageIterator {
+	iter := NewPageIterator(u.client, data, usageTriggersPathPart)
+	return &UsageTriggerPageIterator{
+		p: iter,
+	}
+}
+
+// Next returns the next page of resources. If there are no more resources,
+// NoMoreResults is returned.
+func (u *UsageTriggerPageIterator) Next(ctx context.Context) (*UsageTriggerPage, error) {
+	up := new(UsageTriggerPage)
+	err := u.p.Next(ctx, up)
+	if err != nil {
+		return nil, err
+	}
+	u.p.SetNextPageURI(up.NextPageURI)
+	return up, nil
+}
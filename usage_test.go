@@ -0,0 +1,57 @@
+package twilio
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func TestUsageRecordsMonthly(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(usageRecordPage)
+	defer server.Close()
+	data := url.Values{}
+	data.Set("Category", string(UsageCategorySMS))
+	page, err := client.UsageRecords.GetIntervalPage(context.Background(), UsageIntervalMonthly, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.UsageRecords) != 2 {
+		t.Fatalf("expected 2 usage records, got %d", len(page.UsageRecords))
+	}
+	r := page.UsageRecords[0]
+	if r.Category != UsageCategorySMS || r.Count != "1604" || !r.StartDate.Valid || r.StartDate.Time.Day() != 1 {
+		t.Errorf("bad usage record: %#v", r)
+	}
+	if p := r.FriendlyPrice(); p != "$12.712" {
+		t.Errorf("expected FriendlyPrice to be $12.712, got %s", p)
+	}
+	if p := page.UsageRecords[1].FriendlyPrice(); p != "$0" {
+		t.Errorf("expected FriendlyPrice to be $0, got %s", p)
+	}
+	want := "/2010-04-01/Accounts/AC123/Usage/Records/Monthly.json"
+	if server.URLs[0].Path != want {
+		t.Errorf("request path:\ngot  %q\nwant %q", server.URLs[0].Path, want)
+	}
+}
+
+func TestUsageTriggerCreate(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(usageTriggerInstance)
+	defer server.Close()
+	data := url.Values{}
+	data.Set("CallbackUrl", "https://example.com/usage")
+	data.Set("TriggerValue", "100")
+	data.Set("UsageCategory", string(UsageCategorySMS))
+	trigger, err := client.UsageTriggers.Create(context.Background(), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trigger.Sid != "UT33c6aeeba34e48f38d6899ea5b765ad4" || trigger.UsageCategory != UsageCategorySMS || trigger.DateFired.Valid {
+		t.Errorf("bad usage trigger: %#v", trigger)
+	}
+	want := "/2010-04-01/Accounts/AC123/Usage/Triggers.json"
+	if server.URLs[0].Path != want {
+		t.Errorf("request path:\ngot  %q\nwant %q", server.URLs[0].Path, want)
+	}
+}
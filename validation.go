@@ -1,20 +1,28 @@
 package twilio
 
 import (
+	"bytes"
 	"crypto/hmac"
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"sort"
+	"strings"
 )
 
 // ValidateIncomingRequest returns an error if the incoming req could not be
 // validated as coming from Twilio.
 //
 // This process is frequently error prone, especially if you are running behind
-// a proxy, or Twilio is making requests with a port in the URL.
+// a proxy, or Twilio is making requests with a port in the URL; consider using
+// a RequestValidator instead.
 // See https://www.twilio.com/docs/security#validating-requests for more information
 func ValidateIncomingRequest(host string, authToken string, req *http.Request) (err error) {
 	err = req.ParseForm()
@@ -31,8 +39,8 @@ func ValidateIncomingRequest(host string, authToken string, req *http.Request) (
 
 func validateIncomingRequest(host string, authToken string, URL string, postForm url.Values, xTwilioSignature string) (err error) {
 	expectedTwilioSignature := GetExpectedTwilioSignature(host, authToken, URL, postForm)
-	if xTwilioSignature != expectedTwilioSignature {
-		err = errors.New("twilio: received X-Twilio-Signature value that does not match expected value")
+	if !hmac.Equal([]byte(xTwilioSignature), []byte(expectedTwilioSignature)) {
+		err = errInvalidSignature
 		return
 	}
 
@@ -73,3 +81,189 @@ func GetExpectedTwilioSignature(host string, authToken string, URL string, postF
 	// If they match, then you're good to go.
 	return expectedTwilioSignature
 }
+
+// maxBodySize is the largest JSON body Validate will read, the same limit
+// http.Request.ParseForm applies to form bodies.
+const maxBodySize = 10 << 20
+
+var errInvalidSignature = errors.New("twilio: received X-Twilio-Signature value that does not match expected value")
+
+// A RequestValidator checks that incoming webhook requests were signed by
+// Twilio. Use Handler to reject unsigned requests before they reach your
+// application.
+//
+// Compared with ValidateIncomingRequest, a RequestValidator handles several
+// common problems:
+//
+//   - Twilio may or may not include the port in the URL it signs, so both
+//     forms are tried.
+//   - Behind a load balancer, the scheme and host of the original request are
+//     read from the X-Forwarded-Proto and X-Forwarded-Host headers, but only if
+//     the request came from one of the TrustedProxies. The headers are ignored
+//     by default.
+//   - Requests with a JSON body are signed over the URL, with a bodySHA256
+//     query parameter that is checked against the body.
+//   - Several auth tokens may be accepted at once, to support rotating your
+//     auth token without downtime.
+type RequestValidator struct {
+	// AuthTokens are the auth tokens that may have signed the request. The
+	// first one is usually the current token, and the rest are tokens that
+	// are being rotated out.
+	AuthTokens []string
+
+	// BaseURL, if set, is the scheme and host Twilio uses to reach you, e.g.
+	// "https://example.com". It overrides the scheme and host of the request.
+	BaseURL string
+
+	// TrustedProxies lists the networks of proxies that are allowed to set the
+	// X-Forwarded-Proto and X-Forwarded-Host headers. If it's empty, the
+	// headers are ignored. If a header has several comma separated values,
+	// the last one is used, since it was added by the proxy in front of
+	// you; earlier values may have come from the client. If there is more
+	// than one proxy in front of you, set BaseURL instead.
+	TrustedProxies []*net.IPNet
+
+	// Logger, if non-nil, is called with the reason a request was rejected.
+	Logger func(r *http.Request, err error)
+}
+
+// NewRequestValidator returns a RequestValidator that accepts requests signed
+// with any of the given auth tokens.
+func NewRequestValidator(authTokens ...string) *RequestValidator {
+	return &RequestValidator{AuthTokens: authTokens}
+}
+
+// Handler returns a http.Handler that calls h if the request has a valid
+// X-Twilio-Signature, and otherwise responds with 403 Forbidden.
+//
+// Form parameters are parsed before h is called, and are available in
+// r.PostForm. The request body can still be read for JSON requests.
+func (v *RequestValidator) Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := v.Validate(r); err != nil {
+			if v.Logger != nil {
+				v.Logger(r, err)
+			}
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// Validate returns an error if r could not be validated as coming from Twilio.
+func (v *RequestValidator) Validate(r *http.Request) error {
+	sig := r.Header.Get("X-Twilio-Signature")
+	if sig == "" {
+		return errors.New("twilio: request has no X-Twilio-Signature header")
+	}
+	if len(v.AuthTokens) == 0 {
+		return errors.New("twilio: no auth tokens configured to validate request")
+	}
+	var form url.Values
+	if bodyHash := r.URL.Query().Get("bodySHA256"); bodyHash != "" {
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+		if err != nil {
+			return err
+		}
+		r.Body.Close()
+		if len(body) > maxBodySize {
+			return errors.New("twilio: request body too large")
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		sum := sha256.Sum256(body)
+		if !hmac.Equal([]byte(strings.ToLower(bodyHash)), []byte(hex.EncodeToString(sum[:]))) {
+			return errors.New("twilio: request body does not match bodySHA256 parameter")
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			return err
+		}
+		form = r.PostForm
+	}
+	for _, base := range v.baseURLs(r) {
+		for _, token := range v.AuthTokens {
+			expected := GetExpectedTwilioSignature(base, token, r.URL.RequestURI(), form)
+			if hmac.Equal([]byte(sig), []byte(expected)) {
+				return nil
+			}
+		}
+	}
+	return errInvalidSignature
+}
+
+// baseURLs returns the scheme and host Twilio may have signed, with and
+// without the port.
+func (v *RequestValidator) baseURLs(r *http.Request) []string {
+	var scheme, host string
+	if v.BaseURL != "" {
+		u, err := url.Parse(v.BaseURL)
+		if err == nil {
+			scheme, host = u.Scheme, u.Host
+		}
+	}
+	if scheme == "" {
+		scheme, host = "http", r.Host
+		if r.TLS != nil {
+			scheme = "https"
+		}
+		if v.trusted(r) {
+			if proto := lastHeaderValue(r, "X-Forwarded-Proto"); proto != "" {
+				scheme = proto
+			}
+			if fhost := lastHeaderValue(r, "X-Forwarded-Host"); fhost != "" {
+				host = fhost
+			}
+		}
+	}
+	urls := []string{scheme + "://" + host}
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		if strings.Contains(hostname, ":") {
+			hostname = "[" + hostname + "]"
+		}
+		urls = append(urls, scheme+"://"+hostname)
+	} else {
+		port := "80"
+		if scheme == "https" {
+			port = "443"
+		}
+		urls = append(urls, scheme+"://"+net.JoinHostPort(strings.Trim(host, "[]"), port))
+	}
+	return urls
+}
+
+// trusted reports whether r came from one of the TrustedProxies.
+func (v *RequestValidator) trusted(r *http.Request) bool {
+	if len(v.TrustedProxies) == 0 {
+		return false
+	}
+	addr, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		addr = r.RemoteAddr
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, network := range v.TrustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastHeaderValue returns the last entry in a comma separated header, which
+// is the value set by the proxy closest to the server. Earlier entries may be
+// set by the client.
+func lastHeaderValue(r *http.Request, key string) string {
+	vals := r.Header.Values(key)
+	if len(vals) == 0 {
+		return ""
+	}
+	val := vals[len(vals)-1]
+	if i := strings.LastIndexByte(val, ','); i >= 0 {
+		val = val[i+1:]
+	}
+	return strings.TrimSpace(val)
+}
@@ -1,7 +1,14 @@
 package twilio
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -31,3 +38,106 @@ func TestClientValidateIncomingRequest(t *testing.T) {
 		t.Fatal("Expected an error but got none")
 	}
 }
+
+func signedRequest(t *testing.T, target string, token string, form url.Values) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest("POST", target, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	sig := GetExpectedTwilioSignature("https://mycompany.com", token, "/myapp?foo=1", form)
+	req.Header.Set("X-Twilio-Signature", sig)
+	return req
+}
+
+func TestRequestValidatorHandler(t *testing.T) {
+	t.Parallel()
+	form := url.Values{"CallSid": {"CA123"}, "Digits": {"1234"}}
+	var logged error
+	v := NewRequestValidator("new-token", "old-token")
+	v.Logger = func(r *http.Request, err error) { logged = err }
+	h := v.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.PostForm.Get("Digits") != "1234" {
+			t.Errorf("expected form to be parsed, got %v", r.PostForm)
+		}
+		w.WriteHeader(204)
+	}))
+	tests := []struct {
+		name   string
+		target string
+		token  string
+		want   int
+	}{
+		{"current token", "https://mycompany.com/myapp?foo=1", "new-token", 204},
+		{"rotated token", "https://mycompany.com/myapp?foo=1", "old-token", 204},
+		{"port in URL", "https://mycompany.com:443/myapp?foo=1", "new-token", 204},
+		{"wrong token", "https://mycompany.com/myapp?foo=1", "bad-token", 403},
+		{"wrong scheme", "http://mycompany.com/myapp?foo=1", "new-token", 403},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, signedRequest(t, tt.target, tt.token, form))
+		if w.Code != tt.want {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.want, w.Code)
+		}
+	}
+	if logged != errInvalidSignature {
+		t.Errorf("expected rejection to be logged, got %v", logged)
+	}
+}
+
+func TestRequestValidatorProxy(t *testing.T) {
+	t.Parallel()
+	form := url.Values{"CallSid": {"CA123"}}
+	_, network, err := net.ParseCIDR("10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := NewRequestValidator("token")
+	v.TrustedProxies = []*net.IPNet{network}
+	newReq := func(remoteAddr string) *http.Request {
+		req := signedRequest(t, "http://10.1.2.3:8080/myapp?foo=1", "token", form)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-Proto", "https")
+		// The client sent "attacker.com"; the proxy appended the real host.
+		req.Header.Set("X-Forwarded-Host", "attacker.com, mycompany.com")
+		return req
+	}
+	if err := v.Validate(newReq("10.0.0.5:1234")); err != nil {
+		t.Errorf("expected request from trusted proxy to validate, got %v", err)
+	}
+	if err := v.Validate(newReq("192.0.2.1:1234")); err == nil {
+		t.Error("expected forwarded headers from untrusted address to be ignored")
+	}
+	spoofed := newReq("10.0.0.5:1234")
+	spoofed.Header.Set("X-Forwarded-Host", "mycompany.com, 10.1.2.3")
+	if err := v.Validate(spoofed); err == nil {
+		t.Error("expected the client-supplied first X-Forwarded-Host to be ignored")
+	}
+}
+
+func TestRequestValidatorBodySHA256(t *testing.T) {
+	t.Parallel()
+	body := `{"event": "ringing"}`
+	sum := sha256.Sum256([]byte(body))
+	path := "/myapp?bodySHA256=" + hex.EncodeToString(sum[:])
+	v := NewRequestValidator("token")
+	newReq := func(body string) *http.Request {
+		req := httptest.NewRequest("POST", "https://mycompany.com"+path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Twilio-Signature", GetExpectedTwilioSignature("https://mycompany.com", "token", path, nil))
+		return req
+	}
+	req := newReq(body)
+	if err := v.Validate(req); err != nil {
+		t.Fatal(err)
+	}
+	rest, _ := ioutil.ReadAll(req.Body)
+	if string(rest) != body {
+		t.Errorf("expected body to be readable after validation, got %q", rest)
+	}
+	if err := v.Validate(newReq(`{"event": "busy"}`)); err == nil {
+		t.Error("expected tampered body to fail validation")
+	}
+	if err := v.Validate(newReq(strings.Repeat(" ", maxBodySize+1))); err == nil {
+		t.Error("expected oversized body to fail validation")
+	}
+}
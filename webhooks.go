@@ -0,0 +1,312 @@
+package twilio
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// The functions in this file parse the parameters Twilio sends to your
+// webhooks. They do not check that the request came from Twilio; call
+// ValidateIncomingRequest before parsing the request.
+//
+// For the full list of parameters, see
+// https://www.twilio.com/docs/messaging/guides/webhook-request and
+// https://www.twilio.com/docs/voice/twiml#request-parameters.
+
+// A Location is the geographic data Twilio attaches to a phone number in a
+// webhook request. Any of the fields may be empty.
+type Location struct {
+	City    string
+	State   string
+	Zip     string
+	Country string
+}
+
+// IncomingMessage is the data Twilio sends when a phone number receives an
+// SMS, MMS or WhatsApp message.
+type IncomingMessage struct {
+	MessageSid          string
+	AccountSid          string
+	MessagingServiceSid string
+	From                PhoneNumber
+	To                  PhoneNumber
+	Body                string
+	NumMedia            NumMedia
+	NumSegments         Segments
+	// MediaURLs and MediaContentTypes hold the MediaUrlN and
+	// MediaContentTypeN parameters, in order.
+	MediaURLs         []string
+	MediaContentTypes []string
+	FromLocation      Location
+	ToLocation        Location
+	Status            Status
+	APIVersion        string
+	// WhatsApp only.
+	ProfileName string
+	WaID        string
+	// Extra holds any parameters not listed above, including query
+	// parameters in the webhook URL.
+	Extra map[string]string
+}
+
+// MessageStatusCallback is the data Twilio sends to a message's
+// StatusCallback URL when its status changes.
+type MessageStatusCallback struct {
+	MessageSid          string
+	AccountSid          string
+	MessagingServiceSid string
+	From                PhoneNumber
+	To                  PhoneNumber
+	Status              Status
+	ErrorCode           Code
+	APIVersion          string
+	// Extra holds any parameters not listed above.
+	Extra map[string]string
+}
+
+// VoiceRequest is the data Twilio sends when a call is answered or a TwiML
+// verb (Gather, Record, Dial, etc) finishes.
+type VoiceRequest struct {
+	CallSid       string
+	AccountSid    string
+	From          PhoneNumber
+	To            PhoneNumber
+	Status        Status
+	Direction     Direction
+	ForwardedFrom PhoneNumber
+	CallerName    string
+	ParentCallSid string
+	FromLocation  Location
+	ToLocation    Location
+	APIVersion    string
+	// Set by the Gather verb.
+	Digits       string
+	SpeechResult string
+	// Extra holds any parameters not listed above.
+	Extra map[string]string
+}
+
+// CallStatusCallback is the data Twilio sends to a call's StatusCallback URL
+// when the call changes state.
+type CallStatusCallback struct {
+	VoiceRequest
+	CallDuration      TwilioDuration
+	Timestamp         TwilioTime
+	CallbackSource    string
+	SequenceNumber    int
+	AnsweredBy        AnsweredBy
+	RecordingURL      string
+	RecordingSid      string
+	RecordingDuration TwilioDuration
+}
+
+// ParseIncomingMessage parses the parameters of an incoming message webhook.
+func ParseIncomingMessage(r *http.Request) (*IncomingMessage, error) {
+	f, err := newFormReader(r)
+	if err != nil {
+		return nil, err
+	}
+	m := &IncomingMessage{
+		// SmsSid and SmsMessageSid are older names for MessageSid.
+		MessageSid:          f.first("MessageSid", "SmsMessageSid", "SmsSid"),
+		AccountSid:          f.get("AccountSid"),
+		MessagingServiceSid: f.get("MessagingServiceSid"),
+		From:                PhoneNumber(f.get("From")),
+		To:                  PhoneNumber(f.get("To")),
+		Body:                f.get("Body"),
+		FromLocation:        f.location("From"),
+		ToLocation:          f.location("To"),
+		Status:              Status(f.get("SmsStatus")),
+		APIVersion:          f.get("ApiVersion"),
+		ProfileName:         f.get("ProfileName"),
+		WaID:                f.get("WaId"),
+	}
+	numMedia, err := f.uint("NumMedia")
+	if err != nil {
+		return nil, err
+	}
+	if numMedia > MaxMediaURLs {
+		return nil, fmt.Errorf("twilio: invalid NumMedia value: %d is more than the maximum of %d", numMedia, MaxMediaURLs)
+	}
+	m.NumMedia = NumMedia(numMedia)
+	numSegments, err := f.uint("NumSegments")
+	if err != nil {
+		return nil, err
+	}
+	m.NumSegments = Segments(numSegments)
+	for i := uint64(0); i < numMedia; i++ {
+		n := strconv.FormatUint(i, 10)
+		m.MediaURLs = append(m.MediaURLs, f.get("MediaUrl"+n))
+		m.MediaContentTypes = append(m.MediaContentTypes, f.get("MediaContentType"+n))
+	}
+	m.Extra = f.extra()
+	return m, nil
+}
+
+// ParseMessageStatusCallback parses the parameters of a message status
+// callback.
+func ParseMessageStatusCallback(r *http.Request) (*MessageStatusCallback, error) {
+	f, err := newFormReader(r)
+	if err != nil {
+		return nil, err
+	}
+	m := &MessageStatusCallback{
+		// SmsSid and SmsStatus are older names for MessageSid and
+		// MessageStatus.
+		MessageSid:          f.first("MessageSid", "SmsSid"),
+		AccountSid:          f.get("AccountSid"),
+		MessagingServiceSid: f.get("MessagingServiceSid"),
+		From:                PhoneNumber(f.get("From")),
+		To:                  PhoneNumber(f.get("To")),
+		Status:              Status(f.first("MessageStatus", "SmsStatus")),
+		APIVersion:          f.get("ApiVersion"),
+	}
+	m.ErrorCode, err = f.code("ErrorCode")
+	if err != nil {
+		return nil, err
+	}
+	m.Extra = f.extra()
+	return m, nil
+}
+
+// ParseVoiceRequest parses the parameters of a voice webhook.
+func ParseVoiceRequest(r *http.Request) (*VoiceRequest, error) {
+	f, err := newFormReader(r)
+	if err != nil {
+		return nil, err
+	}
+	v := f.voiceRequest()
+	v.Extra = f.extra()
+	return v, nil
+}
+
+// ParseCallStatusCallback parses the parameters of a call status callback.
+func ParseCallStatusCallback(r *http.Request) (*CallStatusCallback, error) {
+	f, err := newFormReader(r)
+	if err != nil {
+		return nil, err
+	}
+	c := &CallStatusCallback{
+		VoiceRequest:   *f.voiceRequest(),
+		CallbackSource: f.get("CallbackSource"),
+		AnsweredBy:     AnsweredBy(f.get("AnsweredBy")),
+		RecordingURL:   f.get("RecordingUrl"),
+		RecordingSid:   f.get("RecordingSid"),
+	}
+	if c.CallDuration, err = f.duration("CallDuration"); err != nil {
+		return nil, err
+	}
+	if c.RecordingDuration, err = f.duration("RecordingDuration"); err != nil {
+		return nil, err
+	}
+	seq, err := f.uint("SequenceNumber")
+	if err != nil {
+		return nil, err
+	}
+	c.SequenceNumber = int(seq)
+	if ts := f.get("Timestamp"); ts != "" {
+		c.Timestamp = *NewTwilioTime(ts)
+		if !c.Timestamp.Valid {
+			return nil, fmt.Errorf("twilio: invalid Timestamp value: %q", ts)
+		}
+	}
+	c.Extra = f.extra()
+	return c, nil
+}
+
+// formReader reads webhook parameters and remembers which ones were used, so
+// the rest can be returned in an Extra map.
+type formReader struct {
+	form url.Values
+	used map[string]bool
+}
+
+func newFormReader(r *http.Request) (*formReader, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	return &formReader{form: r.Form, used: make(map[string]bool)}, nil
+}
+
+func (f *formReader) get(key string) string {
+	f.used[key] = true
+	return f.form.Get(key)
+}
+
+// first returns the value of the first of keys that is set.
+func (f *formReader) first(keys ...string) string {
+	val := ""
+	for _, key := range keys {
+		if v := f.get(key); val == "" {
+			val = v
+		}
+	}
+	return val
+}
+
+func (f *formReader) uint(key string) (uint64, error) {
+	val := f.get(key)
+	if val == "" {
+		return 0, nil
+	}
+	u, err := strconv.ParseUint(val, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("twilio: invalid %s value: %q", key, val)
+	}
+	return u, nil
+}
+
+func (f *formReader) code(key string) (Code, error) {
+	u, err := f.uint(key)
+	return Code(u), err
+}
+
+func (f *formReader) duration(key string) (TwilioDuration, error) {
+	val := f.get(key)
+	var td TwilioDuration
+	if err := td.UnmarshalJSON([]byte(strconv.Quote(val))); err != nil {
+		return 0, fmt.Errorf("twilio: invalid %s value: %q", key, val)
+	}
+	return td, nil
+}
+
+func (f *formReader) location(prefix string) Location {
+	return Location{
+		City:    f.get(prefix + "City"),
+		State:   f.get(prefix + "State"),
+		Zip:     f.get(prefix + "Zip"),
+		Country: f.get(prefix + "Country"),
+	}
+}
+
+func (f *formReader) voiceRequest() *VoiceRequest {
+	return &VoiceRequest{
+		CallSid:       f.get("CallSid"),
+		AccountSid:    f.get("AccountSid"),
+		From:          PhoneNumber(f.get("From")),
+		To:            PhoneNumber(f.get("To")),
+		Status:        Status(f.get("CallStatus")),
+		Direction:     Direction(f.get("Direction")),
+		ForwardedFrom: PhoneNumber(f.get("ForwardedFrom")),
+		CallerName:    f.get("CallerName"),
+		ParentCallSid: f.get("ParentCallSid"),
+		FromLocation:  f.location("From"),
+		ToLocation:    f.location("To"),
+		APIVersion:    f.get("ApiVersion"),
+		Digits:        f.get("Digits"),
+		SpeechResult:  f.get("SpeechResult"),
+	}
+}
+
+// extra returns the parameters that have not been read with get.
+func (f *formReader) extra() map[string]string {
+	extra := make(map[string]string)
+	for key := range f.form {
+		if !f.used[key] {
+			extra[key] = f.form.Get(key)
+		}
+	}
+	return extra
+}
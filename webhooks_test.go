@@ -0,0 +1,158 @@
+package twilio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newWebhookRequest(target string, v url.Values) *http.Request {
+	req := httptest.NewRequest("POST", target, strings.NewReader(v.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestParseIncomingMessage(t *testing.T) {
+	t.Parallel()
+	v := url.Values{
+		"MessageSid":        {"MM123"},
+		"SmsSid":            {"MM123"},
+		"AccountSid":        {"AC123"},
+		"From":              {"+14105551234"},
+		"To":                {"+14105556789"},
+		"Body":              {"hello"},
+		"NumMedia":          {"2"},
+		"NumSegments":       {"1"},
+		"MediaUrl0":         {"https://api.twilio.com/media/ME1"},
+		"MediaContentType0": {"image/jpeg"},
+		"MediaUrl1":         {"https://api.twilio.com/media/ME2"},
+		"MediaContentType1": {"image/png"},
+		"FromCity":          {"BALTIMORE"},
+		"FromState":         {"MD"},
+		"SmsStatus":         {"received"},
+		"OptOutType":        {"STOP"},
+	}
+	m, err := ParseIncomingMessage(newWebhookRequest("/sms?tenant=7", v))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MessageSid != "MM123" || m.From != PhoneNumber("+14105551234") || m.Body != "hello" {
+		t.Errorf("bad message: %#v", m)
+	}
+	if m.NumMedia != 2 || m.NumSegments != 1 || m.Status != StatusReceived {
+		t.Errorf("bad counts or status: %#v", m)
+	}
+	if len(m.MediaURLs) != 2 || m.MediaURLs[1] != "https://api.twilio.com/media/ME2" {
+		t.Errorf("bad MediaURLs: %v", m.MediaURLs)
+	}
+	if len(m.MediaContentTypes) != 2 || m.MediaContentTypes[0] != "image/jpeg" {
+		t.Errorf("bad MediaContentTypes: %v", m.MediaContentTypes)
+	}
+	if m.FromLocation.City != "BALTIMORE" || m.FromLocation.State != "MD" {
+		t.Errorf("bad FromLocation: %#v", m.FromLocation)
+	}
+	if len(m.Extra) != 2 || m.Extra["OptOutType"] != "STOP" || m.Extra["tenant"] != "7" {
+		t.Errorf("bad Extra: %v", m.Extra)
+	}
+}
+
+func TestParseIncomingMessageInvalid(t *testing.T) {
+	t.Parallel()
+	for _, numMedia := range []string{"many", "11", "18446744073709551615"} {
+		_, err := ParseIncomingMessage(newWebhookRequest("/sms", url.Values{"NumMedia": {numMedia}}))
+		if err == nil {
+			t.Fatalf("NumMedia=%s: expected non-nil error, got nil", numMedia)
+		}
+		if !strings.Contains(err.Error(), "NumMedia") {
+			t.Errorf("bad error: %v", err)
+		}
+	}
+}
+
+func TestParseLegacyMessageSid(t *testing.T) {
+	t.Parallel()
+	m, err := ParseIncomingMessage(newWebhookRequest("/sms", url.Values{"SmsMessageSid": {"SM123"}, "SmsSid": {"SM123"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MessageSid != "SM123" || len(m.Extra) != 0 {
+		t.Errorf("bad message: %#v", m)
+	}
+	cb, err := ParseMessageStatusCallback(newWebhookRequest("/status", url.Values{"SmsSid": {"SM456"}, "SmsStatus": {"sent"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cb.MessageSid != "SM456" || cb.Status != StatusSent || len(cb.Extra) != 0 {
+		t.Errorf("bad callback: %#v", cb)
+	}
+}
+
+func TestParseMessageStatusCallback(t *testing.T) {
+	t.Parallel()
+	v := url.Values{
+		"MessageSid":    {"SM123"},
+		"MessageStatus": {"undelivered"},
+		"SmsStatus":     {"undelivered"},
+		"ErrorCode":     {"30003"},
+	}
+	m, err := ParseMessageStatusCallback(newWebhookRequest("/status", v))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Status != StatusUndelivered || m.ErrorCode != CodeUnreachable {
+		t.Errorf("bad callback: %#v", m)
+	}
+	if len(m.Extra) != 0 {
+		t.Errorf("expected no Extra params, got %v", m.Extra)
+	}
+}
+
+func TestParseVoiceRequest(t *testing.T) {
+	t.Parallel()
+	v := url.Values{
+		"CallSid":    {"CA123"},
+		"From":       {"+14105551234"},
+		"To":         {"+14105556789"},
+		"CallStatus": {"ringing"},
+		"Direction":  {"inbound"},
+		"Digits":     {"1234"},
+	}
+	req, err := ParseVoiceRequest(newWebhookRequest("/voice", v))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.CallSid != "CA123" || req.Status != StatusRinging || req.Direction != DirectionInbound || req.Digits != "1234" {
+		t.Errorf("bad voice request: %#v", req)
+	}
+}
+
+func TestParseCallStatusCallback(t *testing.T) {
+	t.Parallel()
+	v := url.Values{
+		"CallSid":        {"CA123"},
+		"CallStatus":     {"completed"},
+		"CallDuration":   {"37"},
+		"Timestamp":      {"Tue, 24 Aug 2021 18:28:21 +0000"},
+		"SequenceNumber": {"3"},
+		"CallbackSource": {"call-progress-events"},
+	}
+	c, err := ParseCallStatusCallback(newWebhookRequest("/call-status", v))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.CallSid != "CA123" || c.Status != StatusCompleted || c.SequenceNumber != 3 {
+		t.Errorf("bad callback: %#v", c)
+	}
+	if c.CallDuration != TwilioDuration(37*time.Second) {
+		t.Errorf("bad CallDuration: %v", c.CallDuration)
+	}
+	if !c.Timestamp.Valid || c.Timestamp.Time.Unix() != 1629829701 {
+		t.Errorf("bad Timestamp: %#v", c.Timestamp)
+	}
+	if len(c.Extra) != 0 {
+		t.Errorf("expected no Extra params, got %v", c.Extra)
+	}
+}